@@ -0,0 +1,148 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+)
+
+var benchmarkFieldCounts = []int{0, 4, 16}
+
+func benchmarkFields(count int) []errors.Field {
+	fields := make([]errors.Field, 0, count)
+	for i := 0; i < count; i++ {
+		fields = append(fields, errors.String("key"+strconv.Itoa(i), "value"))
+	}
+
+	return fields
+}
+
+func BenchmarkNew(b *testing.B) {
+	for _, count := range benchmarkFieldCounts {
+		fields := benchmarkFields(count)
+
+		b.Run(fmt.Sprintf("fields=%d", count), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_ = errors.New("some error", fields...)
+			}
+		})
+	}
+}
+
+func BenchmarkWrap(b *testing.B) {
+	cause := stdErrors.New("cause")
+
+	for _, count := range benchmarkFieldCounts {
+		fields := benchmarkFields(count)
+
+		b.Run(fmt.Sprintf("fields=%d", count), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_ = errors.Wrap(cause, "some error", fields...)
+			}
+		})
+	}
+}
+
+func BenchmarkWrapWithStack(b *testing.B) {
+	cause := stdErrors.New("cause")
+
+	b.Run("stack=first", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_ = errors.Wrap(cause, "some error", errors.StackSkipDepth("stack", 0, errors.StacktraceFirst))
+		}
+	})
+
+	b.Run("stack=full", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_ = errors.Wrap(cause, "some error", errors.Stack("stack"))
+		}
+	})
+}
+
+func BenchmarkAddFields(b *testing.B) {
+	for _, count := range benchmarkFieldCounts {
+		fields := benchmarkFields(count)
+
+		b.Run(fmt.Sprintf("fields=%d", count), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_ = errors.AddFields(errors.New("some error"), fields...)
+			}
+		})
+	}
+}
+
+func BenchmarkFormat(b *testing.B) {
+	for _, count := range benchmarkFieldCounts {
+		err := errors.Wrap(stdErrors.New("cause"), "some error", benchmarkFields(count)...)
+
+		for _, verb := range []string{"%s", "%v", "%+v"} {
+			b.Run(fmt.Sprintf("fields=%d/verb=%s", count, verb), func(b *testing.B) {
+				b.ReportAllocs()
+
+				for i := 0; i < b.N; i++ {
+					_ = fmt.Sprintf(verb, err)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkNewWithStackDepth(b *testing.B) {
+	for _, depth := range []errors.StacktraceDepth{errors.StacktraceNone, errors.StacktraceFirst, errors.StacktraceFull} {
+		b.Run(fmt.Sprintf("depth=%d", depth), func(b *testing.B) {
+			errors.SetStackDepth(depth)
+			defer errors.SetStackDepth(errors.StacktraceNone)
+
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_ = errors.New("some error")
+			}
+		})
+	}
+}
+
+func BenchmarkEncodeJSON(b *testing.B) {
+	for _, count := range benchmarkFieldCounts {
+		fields := benchmarkFields(count)
+
+		b.Run(fmt.Sprintf("fields=%d", count), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_ = errors.Encode(fields, errors.NewJSONEncoder())
+			}
+		})
+	}
+}
+
+func BenchmarkStackField(b *testing.B) {
+	b.Run("eager", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_ = errors.Stack("stack")
+		}
+	})
+
+	b.Run("lazy", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_ = errors.LazyStack("stack")
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package errors
+
+import "time"
+
+// Builder is a fluent API to construct rich errors in one expression, see Build.
+type Builder struct {
+	msg        string
+	cause      error
+	fields     []Field
+	stackDepth StacktraceDepth
+}
+
+// Build start building an error with the message.
+//
+//	return errors.Build("user not found").Code(errors.CodeNotFound).Field(errors.String("id", id)).Stack().Err()
+func Build(msg string) *Builder {
+	return &Builder{msg: msg, stackDepth: GetStackDepth()}
+}
+
+// Cause set the cause of the error, like Wrap.
+func (b *Builder) Cause(cause error) *Builder {
+	b.cause = cause
+
+	return b
+}
+
+// Field add the fields to the error.
+func (b *Builder) Field(fields ...Field) *Builder {
+	b.fields = append(b.fields, fields...)
+
+	return b
+}
+
+// Code set the code of the error, see CodeField.
+func (b *Builder) Code(code Code) *Builder {
+	return b.Field(CodeField(code))
+}
+
+// Severity set the severity of the error, see SeverityField.
+func (b *Builder) Severity(severity Severity) *Builder {
+	return b.Field(SeverityField(severity))
+}
+
+// HTTPStatus set the intended HTTP status of the error, see HTTPStatusField.
+func (b *Builder) HTTPStatus(status int) *Builder {
+	return b.Field(HTTPStatusField(status))
+}
+
+// Tags add the tags to the error, see WithTags.
+func (b *Builder) Tags(tags ...string) *Builder {
+	return b.Field(TagsField(tags...))
+}
+
+// Retryable mark the error as retryable, see MarkRetryable.
+func (b *Builder) Retryable() *Builder {
+	return b.Field(Bool(KeyRetryable, true))
+}
+
+// RetryableAfter mark the error as retryable with delay, see RetryableAfter.
+func (b *Builder) RetryableAfter(delay time.Duration) *Builder {
+	return b.Field(Bool(KeyRetryable, true), Duration(KeyRetryAfter, delay))
+}
+
+// Stack record the full stack trace when Err is called, even if it's disabled by SetStackDepth.
+func (b *Builder) Stack() *Builder {
+	return b.StackDepth(StacktraceFull)
+}
+
+// StackDepth record the stack trace with the depth when Err is called.
+func (b *Builder) StackDepth(depth StacktraceDepth) *Builder {
+	b.stackDepth = depth
+
+	return b
+}
+
+// Err create the error, every call creates a new error.
+func (b *Builder) Err() error {
+	return wrapDepth(1, b.cause, b.msg, copyFields(b.fields, 0), b.stackDepth) // skip Err
+}
@@ -0,0 +1,65 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all attributes are set, expect error with all of them", func(t *testing.T) {
+		cause := stdErrors.New("no rows")
+
+		err := errors.Build("user not found").
+			Cause(cause).
+			Code(errors.CodeNotFound).
+			Severity(errors.SeverityInfo).
+			HTTPStatus(http.StatusGone).
+			Tags("db").
+			RetryableAfter(time.Second).
+			Field(errors.String("id", "1")).
+			Stack().
+			Err()
+
+		delay, _ := errors.RetryAfter(err)
+
+		assert.Equal(t, "user not found: no rows", err.Error())
+		assert.ErrorIs(t, err, cause)
+		assert.Equal(t, errors.CodeNotFound, errors.GetCode(err))
+		assert.Equal(t, errors.SeverityInfo, errors.GetSeverity(err))
+		assert.Equal(t, http.StatusGone, errors.HTTPStatus(err))
+		assert.True(t, errors.HasTag(err, "db"))
+		assert.True(t, errors.IsRetryable(err))
+		assert.Equal(t, time.Second, delay)
+		assert.Equal(t, "1", errors.GetField(err, "id").Str)
+
+		trace := err.(*errors.Error).StackTrace()
+		assert.True(t, strings.HasPrefix(trace, "github.com/mrsoftware/errors_test.TestBuilder"), trace)
+	})
+
+	t.Run("only message, expect simple error without stack", func(t *testing.T) {
+		err := errors.Build("some error").Retryable().Err()
+
+		assert.Equal(t, "some error", err.Error())
+		assert.True(t, errors.IsRetryable(err))
+		assert.Empty(t, err.(*errors.Error).StackTrace())
+	})
+
+	t.Run("err is called twice, expect independent errors", func(t *testing.T) {
+		builder := errors.Build("some error").Code(errors.CodeInternal)
+
+		err1 := builder.Err()
+		err2 := builder.Err()
+		errors.AddFields(err1, errors.String("key", "value"))
+
+		assert.NotSame(t, err1, err2)
+		assert.Len(t, errors.GetFields(err2), 1)
+	})
+}
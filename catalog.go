@@ -0,0 +1,98 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	// CatalogFormatJSON is used to write the catalog as JSON.
+	CatalogFormatJSON = "json"
+
+	// CatalogFormatMarkdown is used to write the catalog as Markdown tables.
+	CatalogFormatMarkdown = "markdown"
+)
+
+// Catalog is the list of registered error definitions.
+type Catalog struct {
+	Codes []CodeInfo `json:"codes"`
+}
+
+// GetCatalog return the current catalog, the codes have their HTTP status and kinds.
+// codes of the templates in the default registry are included even if they are not registered by RegisterCode.
+func GetCatalog() Catalog {
+	codes := Codes()
+
+	kinds := make(map[Code][]string)
+	for _, name := range defaultRegistry.Names() {
+		template, _ := defaultRegistry.Template(name)
+		kinds[template.Code] = append(kinds[template.Code], name)
+	}
+
+	registered := make(map[Code]bool, len(codes))
+	for _, info := range codes {
+		registered[info.Code] = true
+	}
+
+	for code := range kinds {
+		if code != "" && !registered[code] {
+			codes = append(codes, CodeInfo{Code: code})
+		}
+	}
+
+	sort.Slice(codes, func(i, j int) bool { return codes[i].Code < codes[j].Code })
+
+	for i := range codes {
+		codes[i].HTTPStatus = codeHTTPStatus(codes[i].Code)
+		codes[i].Kinds = kinds[codes[i].Code]
+	}
+
+	return Catalog{Codes: codes}
+}
+
+// WriteCatalog write the catalog of registered codes in requested format (CatalogFormatJSON or CatalogFormatMarkdown).
+func WriteCatalog(w io.Writer, format string) error {
+	catalog := GetCatalog()
+
+	switch format {
+	case CatalogFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(catalog)
+	case CatalogFormatMarkdown:
+		return writeMarkdownCatalog(w, catalog)
+	default:
+		return New("unsupported catalog format", String("format", format))
+	}
+}
+
+func writeMarkdownCatalog(w io.Writer, catalog Catalog) error {
+	builder := &strings.Builder{}
+
+	builder.WriteString("## Codes\n\n")
+	builder.WriteString("| Code | HTTP Status | Kinds | Description |\n")
+	builder.WriteString("| ---- | ----------- | ----- | ----------- |\n")
+
+	for _, info := range catalog.Codes {
+		kinds := make([]string, 0, len(info.Kinds))
+		for _, kind := range info.Kinds {
+			kinds = append(kinds, "`"+kind+"`")
+		}
+
+		fmt.Fprintf(builder, "| `%s` | %d | %s | %s |\n", info.Code, info.HTTPStatus, strings.Join(kinds, ", "), markdownCell(info.Description))
+	}
+
+	_, err := io.WriteString(w, builder.String())
+
+	return err
+}
+
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+
+	return strings.ReplaceAll(value, "\n", " ")
+}
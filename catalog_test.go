@@ -0,0 +1,61 @@
+package errors_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCatalog(t *testing.T) {
+	t.Parallel()
+
+	errors.RegisterCode("test_catalog", "catalog | test")
+	errors.RegisterHTTPStatus("test_catalog", http.StatusTeapot)
+
+	t.Cleanup(func() { errors.UnregisterTemplate("test.catalog") })
+	require.NoError(t, errors.Register("test.catalog", errors.Template{Code: "test_catalog", Message: "catalog"}))
+
+	t.Cleanup(func() { errors.UnregisterTemplate("test.catalog_unregistered") })
+	require.NoError(t, errors.Register("test.catalog_unregistered", errors.Template{Code: "test_catalog_unregistered", Message: "catalog"}))
+
+	t.Run("json format, expect to get registered codes", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		require.NoError(t, errors.WriteCatalog(buffer, errors.CatalogFormatJSON))
+
+		var catalog errors.Catalog
+		require.NoError(t, json.Unmarshal(buffer.Bytes(), &catalog))
+		assert.Contains(t, catalog.Codes, errors.CodeInfo{
+			Code:        "test_catalog",
+			Description: "catalog | test",
+			HTTPStatus:  http.StatusTeapot,
+			Kinds:       []string{"test.catalog"},
+		})
+		assert.Contains(t, catalog.Codes, errors.CodeInfo{
+			Code:       "test_catalog_unregistered",
+			HTTPStatus: http.StatusInternalServerError,
+			Kinds:      []string{"test.catalog_unregistered"},
+		})
+		for _, info := range catalog.Codes {
+			if info.Code == errors.CodeNotFound {
+				assert.Equal(t, http.StatusNotFound, info.HTTPStatus)
+			}
+		}
+	})
+
+	t.Run("markdown format, expect to get a table", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		require.NoError(t, errors.WriteCatalog(buffer, errors.CatalogFormatMarkdown))
+
+		assert.Contains(t, buffer.String(), "| Code | HTTP Status | Kinds | Description |")
+		assert.Contains(t, buffer.String(), "| `test_catalog` | 418 | `test.catalog` | catalog \\| test |")
+	})
+
+	t.Run("unknown format, expect error", func(t *testing.T) {
+		assert.Error(t, errors.WriteCatalog(&bytes.Buffer{}, "xml"))
+	})
+}
@@ -0,0 +1,80 @@
+package errors
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	clock       atomic.Value // func() time.Time
+	idGenerator atomic.Value // func() string
+)
+
+// SetClock set the clock used by all time producing features, nil resets it to time.Now.
+// it's useful to get deterministic errors in tests.
+func SetClock(now func() time.Time) {
+	if now == nil {
+		now = time.Now
+	}
+
+	clock.Store(now)
+}
+
+// SetIDGenerator set the generator used by all ID producing features, nil resets it to the default
+// random UUID (version 4) generator.
+func SetIDGenerator(generate func() string) {
+	if generate == nil {
+		generate = NewUUID
+	}
+
+	idGenerator.Store(generate)
+}
+
+// now return the current time of the clock.
+func now() time.Time {
+	if now, ok := clock.Load().(func() time.Time); ok {
+		return now()
+	}
+
+	return time.Now()
+}
+
+// newID return a new ID of the ID generator.
+func newID() string {
+	if generate, ok := idGenerator.Load().(func() string); ok {
+		return generate()
+	}
+
+	return NewUUID()
+}
+
+// NewUUID return a random UUID (version 4).
+func NewUUID() string {
+	var uuid [16]byte
+	if _, err := rand.Read(uuid[:]); err != nil {
+		// crypto/rand never fails on supported platforms, the time is a fallback.
+		nano := time.Now().UnixNano()
+		for i := range uuid {
+			uuid[i] = byte(nano >> (i % 8 * 8))
+		}
+	}
+
+	uuid[6] = (uuid[6] & 0x0f) | 0x40 // version 4
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // variant 10
+
+	var buffer [36]byte
+
+	hex.Encode(buffer[0:8], uuid[0:4])
+	buffer[8] = '-'
+	hex.Encode(buffer[9:13], uuid[4:6])
+	buffer[13] = '-'
+	hex.Encode(buffer[14:18], uuid[6:8])
+	buffer[18] = '-'
+	hex.Encode(buffer[19:23], uuid[8:10])
+	buffer[23] = '-'
+	hex.Encode(buffer[24:], uuid[10:])
+
+	return string(buffer[:])
+}
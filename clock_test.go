@@ -0,0 +1,34 @@
+package errors
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetClock(t *testing.T) {
+	fixed := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	SetClock(func() time.Time { return fixed })
+	assert.Equal(t, fixed, now())
+
+	SetClock(nil)
+	assert.NotEqual(t, fixed, now())
+}
+
+func TestSetIDGenerator(t *testing.T) {
+	SetIDGenerator(func() string { return "fixed-id" })
+	assert.Equal(t, "fixed-id", newID())
+
+	SetIDGenerator(nil)
+	assert.NotEqual(t, "fixed-id", newID())
+}
+
+func TestNewUUID(t *testing.T) {
+	uuid := NewUUID()
+
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), uuid)
+	assert.NotEqual(t, uuid, NewUUID())
+}
@@ -0,0 +1,123 @@
+package errors
+
+import (
+	"sort"
+	"sync"
+)
+
+// Code is a machine readable classification of an error.
+type Code string
+
+const (
+	// CodeUnknown is used if the error has no code.
+	CodeUnknown Code = "unknown"
+
+	// CodeInternal is used for unexpected failures.
+	CodeInternal Code = "internal"
+
+	// CodeInvalidArgument is used if the input is not valid.
+	CodeInvalidArgument Code = "invalid_argument"
+
+	// CodeNotFound is used if the requested entity is not found.
+	CodeNotFound Code = "not_found"
+
+	// CodeAlreadyExists is used if the entity that we want to create already exists.
+	CodeAlreadyExists Code = "already_exists"
+
+	// CodePermissionDenied is used if the caller has no permission to do the operation.
+	CodePermissionDenied Code = "permission_denied"
+
+	// CodeUnauthenticated is used if the caller is not authenticated.
+	CodeUnauthenticated Code = "unauthenticated"
+
+	// CodeUnavailable is used if the service is currently unavailable.
+	CodeUnavailable Code = "unavailable"
+
+	// CodeTimeout is used if the operation is timed out.
+	CodeTimeout Code = "timeout"
+
+	// CodeCanceled is used if the operation is canceled.
+	CodeCanceled Code = "canceled"
+)
+
+// CodeInfo is the registered information of a Code.
+type CodeInfo struct {
+	Code        Code   `json:"code"`
+	Description string `json:"description"`
+
+	// HTTPStatus is the HTTP status of the code (see RegisterHTTPStatus), it's set only in the catalog (see GetCatalog).
+	HTTPStatus int `json:"http_status,omitempty"`
+
+	// Kinds are the names of the templates of the default registry that have the code (see Register),
+	// it's set only in the catalog (see GetCatalog).
+	Kinds []string `json:"kinds,omitempty"`
+}
+
+var (
+	codesMx sync.RWMutex
+	codes   = map[Code]CodeInfo{}
+)
+
+func init() { // nolint: gochecknoinits
+	RegisterCode(CodeUnknown, "The error has no code.")
+	RegisterCode(CodeInternal, "Unexpected failure.")
+	RegisterCode(CodeInvalidArgument, "The input is not valid.")
+	RegisterCode(CodeNotFound, "The requested entity is not found.")
+	RegisterCode(CodeAlreadyExists, "The entity already exists.")
+	RegisterCode(CodePermissionDenied, "The caller has no permission to do the operation.")
+	RegisterCode(CodeUnauthenticated, "The caller is not authenticated.")
+	RegisterCode(CodeUnavailable, "The service is currently unavailable.")
+	RegisterCode(CodeTimeout, "The operation is timed out.")
+	RegisterCode(CodeCanceled, "The operation is canceled.")
+}
+
+// RegisterCode add the code to the registered codes, registering an existing code replace it.
+func RegisterCode(code Code, description string) {
+	codesMx.Lock()
+	defer codesMx.Unlock()
+
+	codes[code] = CodeInfo{Code: code, Description: description}
+}
+
+// Codes return all registered codes sorted by code.
+func Codes() []CodeInfo {
+	codesMx.RLock()
+	defer codesMx.RUnlock()
+
+	list := make([]CodeInfo, 0, len(codes))
+	for _, info := range codes {
+		list = append(list, info)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+
+	return list
+}
+
+// CodeField constructs a field that carries the Code.
+func CodeField(code Code) Field {
+	return String(KeyCode, string(code))
+}
+
+// WithCode return the passed error with the code, it overrides the code of the chain and the passed error is not changed.
+func WithCode(err error, code Code) error {
+	if err == nil {
+		return nil
+	}
+
+	return withField(1, err, CodeField(code)) // skip WithCode
+}
+
+// GetCode find the code in error chain, CodeUnknown is returned if there is no code.
+func GetCode(err error) Code {
+	if err == nil {
+		return CodeUnknown
+	}
+
+	field := FindFieldInChain(KeyCode, err)
+	if IsNilField(field) {
+		return CodeUnknown
+	}
+
+	return Code(field.Str)
+}
@@ -0,0 +1,58 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("code is set in chain, expect to find it", func(t *testing.T) {
+		cause := errors.New("cause", errors.CodeField(errors.CodeNotFound))
+		err := fmt.Errorf("wrapper: %w", errors.Wrap(cause, "some error"))
+
+		assert.Equal(t, errors.CodeNotFound, errors.GetCode(err))
+	})
+
+	t.Run("no code in chain, expect to get unknown", func(t *testing.T) {
+		assert.Equal(t, errors.CodeUnknown, errors.GetCode(stdErrors.New("some error")))
+		assert.Equal(t, errors.CodeUnknown, errors.GetCode(nil))
+	})
+}
+
+func TestWithCode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("err is nil, expect nil", func(t *testing.T) {
+		assert.Nil(t, errors.WithCode(nil, errors.CodeInternal))
+	})
+
+	t.Run("err is not nil, expect to add the code", func(t *testing.T) {
+		err := errors.WithCode(stdErrors.New("some error"), errors.CodeInvalidArgument)
+
+		assert.Equal(t, errors.CodeInvalidArgument, errors.GetCode(err))
+	})
+
+	t.Run("err has code, expect the code overridden and err not changed", func(t *testing.T) {
+		cause := errors.New("some error", errors.CodeField(errors.CodeNotFound))
+		err := errors.WithCode(cause, errors.CodeInternal)
+
+		assert.Equal(t, errors.CodeInternal, errors.GetCode(err))
+		assert.Equal(t, errors.CodeNotFound, errors.GetCode(cause))
+		assert.Len(t, errors.GetFields(err), 1)
+	})
+}
+
+func TestRegisterCode(t *testing.T) {
+	t.Parallel()
+
+	code := errors.Code("test_register_code")
+	errors.RegisterCode(code, "registered in test")
+
+	assert.Contains(t, errors.Codes(), errors.CodeInfo{Code: code, Description: "registered in test"})
+}
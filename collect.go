@@ -0,0 +1,81 @@
+package errors
+
+// Collect return MultiError of all non-nil errors, or nil if there is no error.
+func Collect(errs ...error) error {
+	return NewMultiError(errs...).Err()
+}
+
+// Join return MultiError of all non-nil errors, or nil if there is no error, like errors.Join of the standard library.
+// the fields of the joined errors are kept, and can be found by GetChainFields and FindFieldInChain.
+func Join(errs ...error) error {
+	return Collect(errs...)
+}
+
+// Append add the errs to err and return it like Append of hashicorp/go-multierror, so errors can be accumulated
+// in a loop by one call. err can be nil, a MultiError that errs are added to, or an error that becomes the first
+// error of a new MultiError. nil errs are skipped and the errors of MultiErrors in errs are added instead of themselves.
+//
+//	var result *errors.MultiError
+//	for _, item := range items {
+//		result = errors.Append(result, process(item))
+//	}
+//
+//	return result.ErrorOrNil()
+func Append(err error, errs ...error) *MultiError {
+	multi, ok := err.(*MultiError) // nolint: errorlint
+	if !ok {
+		multi = NewMultiError(err)
+	} else if multi == nil {
+		multi = NewMultiError()
+	}
+
+	for _, err := range errs {
+		if nested, ok := err.(*MultiError); ok { // nolint: errorlint
+			if nested != nil {
+				for _, err := range nested.Errors() {
+					multi.Add(err)
+				}
+			}
+
+			continue
+		}
+
+		multi.Add(err)
+	}
+
+	return multi
+}
+
+// Collect2 return v and accumulate the err into the MultiError of errp, so the caller can report all failures at once:
+//
+//	func load() (cfg Config, err error) {
+//		users, usersErr := loadUsers()
+//		cfg.Users = errors.Collect2(users, usersErr, &err)
+//
+//		roles, rolesErr := loadRoles()
+//		cfg.Roles = errors.Collect2(roles, rolesErr, &err)
+//
+//		return cfg, err
+//	}
+func Collect2[T any](v T, err error, errp *error) T {
+	if err == nil {
+		return v
+	}
+
+	switch current := (*errp).(type) { // nolint: errorlint
+	case nil:
+		*errp = NewMultiError(err)
+	case *MultiError:
+		if current == nil {
+			*errp = NewMultiError(err)
+
+			break
+		}
+
+		current.Add(err)
+	default:
+		*errp = NewMultiError(current, err)
+	}
+
+	return v
+}
@@ -0,0 +1,114 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no error, expect nil", func(t *testing.T) {
+		assert.Nil(t, errors.Collect(nil, nil))
+	})
+
+	t.Run("some errors, expect all of them", func(t *testing.T) {
+		error1 := stdErrors.New("error 1")
+		error2 := stdErrors.New("error 2")
+
+		assert.Equal(t, "error 1 | error 2", errors.Collect(error1, nil, error2).Error())
+	})
+}
+
+func TestJoin(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no error, expect nil", func(t *testing.T) {
+		assert.Nil(t, errors.Join(nil, nil))
+	})
+
+	t.Run("errors with fields, expect fields of all errors in chain", func(t *testing.T) {
+		error1 := errors.New("error 1", errors.String("key1", "value1"))
+		error2 := errors.Wrap(stdErrors.New("error 2"), "wrapper", errors.String("key2", "value2"))
+		err := errors.Wrap(errors.Join(error1, nil, error2), "joined", errors.String("key0", "value0"))
+
+		assert.Equal(t, "joined: error 1 | wrapper: error 2", err.Error())
+		assert.ErrorIs(t, err, error1)
+		assert.Equal(t, []errors.Field{
+			errors.String("key0", "value0"),
+			errors.String("key1", "value1"),
+			errors.String("key2", "value2"),
+		}, errors.GetChainFields(err))
+		assert.Equal(t, "value2", errors.FindFieldInChain("key2", err).Str)
+		assert.True(t, errors.IsNilField(errors.FindFieldInChain("key3", err)))
+	})
+}
+
+func TestAppend(t *testing.T) {
+	t.Parallel()
+
+	error1 := stdErrors.New("error 1")
+	error2 := stdErrors.New("error 2")
+	error3 := stdErrors.New("error 3")
+
+	t.Run("nil target, expect new multi error without nil errors", func(t *testing.T) {
+		var result *errors.MultiError
+
+		result = errors.Append(result, nil)
+		result = errors.Append(result, error1, nil)
+
+		assert.Equal(t, []error{error1}, result.Errors())
+	})
+
+	t.Run("nil error target, expect new multi error", func(t *testing.T) {
+		assert.Equal(t, []error{error1}, errors.Append(nil, error1).Errors())
+	})
+
+	t.Run("multi error target, expect errors added to it", func(t *testing.T) {
+		multi := errors.NewMultiError(error1)
+
+		result := errors.Append(multi, error2)
+
+		assert.Same(t, multi, result)
+		assert.Equal(t, []error{error1, error2}, result.Errors())
+	})
+
+	t.Run("error target and multi errors in errs, expect one level list", func(t *testing.T) {
+		result := errors.Append(error1, errors.NewMultiError(error2, error3))
+
+		assert.Equal(t, []error{error1, error2, error3}, result.Errors())
+	})
+}
+
+func TestCollect2(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no error, expect values and nil error", func(t *testing.T) {
+		var err error
+
+		assert.Equal(t, 1, errors.Collect2(1, nil, &err))
+		assert.Nil(t, err)
+	})
+
+	t.Run("some errors, expect values and all errors", func(t *testing.T) {
+		error1 := stdErrors.New("error 1")
+		error2 := stdErrors.New("error 2")
+		err := stdErrors.New("error 0")
+
+		assert.Equal(t, 1, errors.Collect2(1, error1, &err))
+		assert.Equal(t, "2", errors.Collect2("2", nil, &err))
+		assert.Equal(t, 3, errors.Collect2(3, error2, &err))
+		assert.Equal(t, "error 0 | error 1 | error 2", err.Error())
+	})
+
+	t.Run("error is a nil MultiError, expect new MultiError", func(t *testing.T) {
+		var multi *errors.MultiError
+		var err error = multi
+
+		assert.Equal(t, 1, errors.Collect2(1, stdErrors.New("error 1"), &err))
+		assert.Equal(t, "error 1", err.Error())
+	})
+}
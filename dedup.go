@@ -0,0 +1,83 @@
+package errors
+
+import (
+	"strconv"
+	"sync/atomic"
+)
+
+// DedupPolicy define which field is kept when a key is repeated, see DedupFields.
+type DedupPolicy int32
+
+const (
+	// KeepOutermost keep the first field of the key, for chain fields it's the outermost one.
+	KeepOutermost DedupPolicy = iota
+
+	// KeepInnermost keep the last field of the key, for chain fields it's the innermost one.
+	KeepInnermost
+
+	// KeepAll keep all fields, repeated keys get _1, _2, ... suffixes in order.
+	KeepAll
+)
+
+var dedupPolicy = int32(KeepOutermost)
+
+// SetDedupPolicy set the policy that serializers (SlogAttrs, FieldsMap, NewHTTPErrorBody, ...) use
+// for repeated keys in the chain, it's KeepOutermost by default.
+func SetDedupPolicy(policy DedupPolicy) {
+	atomic.StoreInt32(&dedupPolicy, int32(policy))
+}
+
+// GetDedupPolicy return the policy that serializers use for repeated keys.
+func GetDedupPolicy() DedupPolicy {
+	return DedupPolicy(atomic.LoadInt32(&dedupPolicy))
+}
+
+// DedupFields return the fields with unique keys based on the policy, the order of the fields is kept.
+func DedupFields(fields []Field, policy DedupPolicy) []Field {
+	result := make([]Field, 0, len(fields))
+	seen := make(map[string]int, len(fields))
+
+	switch policy {
+	case KeepInnermost:
+		for i := len(fields) - 1; i >= 0; i-- {
+			if _, ok := seen[fields[i].Key]; ok {
+				continue
+			}
+
+			seen[fields[i].Key] = i
+		}
+
+		for i, field := range fields {
+			if seen[field.Key] == i {
+				result = append(result, field)
+			}
+		}
+	case KeepAll:
+		for _, field := range fields {
+			count := seen[field.Key]
+			seen[field.Key] = count + 1
+
+			if count > 0 {
+				field.Key += "_" + strconv.Itoa(count)
+			}
+
+			result = append(result, field)
+		}
+	default:
+		for _, field := range fields {
+			if _, ok := seen[field.Key]; ok {
+				continue
+			}
+
+			seen[field.Key] = 0
+			result = append(result, field)
+		}
+	}
+
+	return result
+}
+
+// DedupChainFields return the fields of the error chain deduplicated with the policy of GetDedupPolicy.
+func DedupChainFields(err error) []Field {
+	return DedupFields(GetChainFields(err), GetDedupPolicy())
+}
@@ -0,0 +1,65 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDedupFields(t *testing.T) {
+	t.Parallel()
+
+	fields := []errors.Field{
+		errors.String("id", "outer"),
+		errors.String("name", "mrsoftware"),
+		errors.String("id", "middle"),
+		errors.String("id", "inner"),
+	}
+
+	t.Run("keep outermost, expect first field of the key", func(t *testing.T) {
+		assert.Equal(t, []errors.Field{errors.String("id", "outer"), errors.String("name", "mrsoftware")}, errors.DedupFields(fields, errors.KeepOutermost))
+	})
+
+	t.Run("keep innermost, expect last field of the key", func(t *testing.T) {
+		assert.Equal(t, []errors.Field{errors.String("name", "mrsoftware"), errors.String("id", "inner")}, errors.DedupFields(fields, errors.KeepInnermost))
+	})
+
+	t.Run("keep all, expect suffixed keys", func(t *testing.T) {
+		assert.Equal(t, []errors.Field{
+			errors.String("id", "outer"),
+			errors.String("name", "mrsoftware"),
+			errors.String("id_1", "middle"),
+			errors.String("id_2", "inner"),
+		}, errors.DedupFields(fields, errors.KeepAll))
+	})
+
+	t.Run("no fields, expect empty", func(t *testing.T) {
+		assert.Empty(t, errors.DedupFields(nil, errors.KeepAll))
+	})
+}
+
+func TestSetDedupPolicy(t *testing.T) {
+	err := errors.Wrap(errors.New("inner", errors.String("id", "inner")), "outer", errors.String("id", "outer"))
+
+	t.Run("default policy, expect outermost value", func(t *testing.T) {
+		assert.Equal(t, errors.KeepOutermost, errors.GetDedupPolicy())
+		assert.Equal(t, map[string]interface{}{"id": "outer"}, errors.FieldsMap(err))
+	})
+
+	t.Run("keep innermost policy, expect innermost value in serializers", func(t *testing.T) {
+		errors.SetDedupPolicy(errors.KeepInnermost)
+		defer errors.SetDedupPolicy(errors.KeepOutermost)
+
+		assert.Equal(t, map[string]interface{}{"id": "inner"}, errors.FieldsMap(err))
+		assert.Equal(t, map[string]interface{}{"id": "inner"}, errors.NewHTTPErrorBody(err).Fields)
+	})
+
+	t.Run("keep all policy, expect all values in serializers", func(t *testing.T) {
+		errors.SetDedupPolicy(errors.KeepAll)
+		defer errors.SetDedupPolicy(errors.KeepOutermost)
+
+		assert.Equal(t, map[string]interface{}{"id": "outer", "id_1": "inner"}, errors.FieldsMap(err))
+		assert.Len(t, errors.SlogAttrs(err), 3)
+	})
+}
@@ -0,0 +1,72 @@
+package errors
+
+import "sync/atomic"
+
+// minChainDepth is the minimum of max chain depth, it's the new layer, the summarizing layer and the root layer.
+const minChainDepth = 3
+
+var maxChainDepth int32
+
+// SetMaxChainDepth set the max number of Error layers on top of the chain, 0 means unlimited (default).
+// when Wrap exceeds it, the middle layers are collapsed into a single layer that keeps the message of the
+// outermost collapsed layer and carries the number of collapsed layers in the KeyCollapsedLayers field,
+// the fields of collapsed layers are dropped. depth less than 3 is considered as 3.
+func SetMaxChainDepth(depth int) {
+	if depth > 0 && depth < minChainDepth {
+		depth = minChainDepth
+	}
+
+	atomic.StoreInt32(&maxChainDepth, int32(depth))
+}
+
+// MaxChainDepth return the max chain depth, 0 means unlimited.
+func MaxChainDepth() int {
+	return int(atomic.LoadInt32(&maxChainDepth))
+}
+
+// collapseChain collapse the top layers of cause if it has more than maxDepth Error layers on top.
+// only the consecutive Error layers on top of the chain are counted, since other errors can not be rebuilt.
+// skip=0 identifies the caller of collapseChain.
+func collapseChain(skip int, cause error, maxDepth int) error {
+	layers := make([]*Error, 0, maxDepth+1)
+
+	for next := cause; ; {
+		layer, ok := next.(*Error) // nolint: errorlint
+		if !ok {
+			break
+		}
+
+		layers = append(layers, layer)
+		next = layer.cause
+	}
+
+	if len(layers) <= maxDepth {
+		return cause
+	}
+
+	// keep the inner layers, one place is for the summarizing layer.
+	keep := maxDepth - 1
+	collapsed := layers[:len(layers)-keep]
+	inner := collapsed[len(collapsed)-1].cause
+
+	count := 0
+	for _, layer := range collapsed {
+		count += collapsedLayers(layer)
+	}
+
+	// the collapsed layers are already notified to the default Stater.
+	fields := []Field{Int(KeyCollapsedLayers, count), NoStat()}
+
+	return wrapDepth(skip+1, inner, collapsed[0].msg, fields, StacktraceNone)
+}
+
+// collapsedLayers return number of layers that the layer represent.
+func collapsedLayers(layer *Error) int {
+	for _, field := range layer.getFields() {
+		if field.Key == KeyCollapsedLayers && field.Type == FieldTypeInt64 {
+			return int(field.Integer)
+		}
+	}
+
+	return 1
+}
@@ -0,0 +1,46 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func chainDepth(err error) int {
+	depth := 0
+	for ; err != nil; err = stdErrors.Unwrap(err) {
+		depth++
+	}
+
+	return depth
+}
+
+func TestSetMaxChainDepth(t *testing.T) {
+	errors.SetMaxChainDepth(4)
+	defer errors.SetMaxChainDepth(0)
+
+	root := stdErrors.New("root")
+
+	var err error = root
+	for i := 0; i < 1000; i++ {
+		err = errors.Wrap(err, "retry")
+	}
+
+	assert.Equal(t, 5, chainDepth(err))
+	assert.True(t, errors.Is(err, root))
+	assert.Equal(t, "retry: retry: retry: retry: root", err.Error())
+
+	collapsed := errors.FindFieldInChain(errors.KeyCollapsedLayers, err)
+	assert.Equal(t, int64(997), collapsed.Value())
+}
+
+func TestSetMaxChainDepth_Unlimited(t *testing.T) {
+	var err error = stdErrors.New("root")
+	for i := 0; i < 100; i++ {
+		err = errors.Wrap(err, "retry")
+	}
+
+	assert.Equal(t, 101, chainDepth(err))
+}
@@ -15,7 +15,8 @@
 //
 // The errors.Wrap function returns a new error that adds context to the
 // original error by recording a stack trace at the point Wrap is called,
-// together with the supplied message. For example
+// together with the supplied message. Recording the stack trace is disabled
+// by default and can be enabled by errors.SetStackDepth. For example
 //
 //	_, err := ioutil.ReadAll(r)
 //	if err != nil {
@@ -61,12 +62,12 @@
 //  user := struct { Username string }{Username: "mrsoftware"}
 //	errors.New("some error", errors.String("name", "mohammad"), errors.Reflect("user", user))
 //
-//	%q    print the error. If the error has a Cause it will be
-//	      printed recursively. the fields value print as a list.
+//	%q    print the %s format as a double-quoted Go string literal,
+//	      so it can always be read back by strconv.Unquote.
 //
 //  sample:
 //
-//  "some error": ["mohammad" {"mrsoftware"}]
+//  "some error: [[name: mohammad] [user: {mrsoftware}]]"
 //
 //	%s    print the error. If the error has a Cause it will be
 //	      printed recursively. the fields key/value print as a list
@@ -83,7 +84,10 @@
 // some error: [{Key: name, Value: mohammad} {Key: user, Value: {Username:mrsoftware}}]
 //
 //	%+v   extended format. If the error has a Cause, it will be
-//	  printed recursively. the fields key/type/value print as a list like struct.
+//	  printed recursively. the fields key/type/value print as a list like struct,
+//	  followed by the creation time and the stack trace if they're recorded.
+//	  if several errors of the chain have a stack trace, the innermost one is printed
+//	  fully and the frames that the next ones share with it are elided.
 //
 // sample:
 //
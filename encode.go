@@ -0,0 +1,375 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+	"unicode"
+)
+
+// Encode add the fields to the encoder, context fields are skipped and
+// the nested fields of Namespace fields are added as an object.
+func Encode(fields []Field, enc FieldEncoder) error {
+	for _, field := range fields {
+		if err := encodeField(field, enc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func encodeField(field Field, enc FieldEncoder) error { // nolint: cyclop
+	switch field.Type { // nolint: exhaustive
+	case FieldTypeString, FieldTypeByteString, FieldTypeStringer, FieldTypeStack:
+		value, _ := field.StringValue()
+		enc.AddString(field.Key, value)
+	case FieldTypeInt64, FieldTypeInt32, FieldTypeInt16, FieldTypeInt8:
+		enc.AddInt64(field.Key, field.Integer)
+	case FieldTypeUint64, FieldTypeUint32, FieldTypeUintptr:
+		enc.AddUint64(field.Key, uint64(field.Integer))
+	case FieldTypeFloat64, FieldTypeFloat32:
+		value, _ := field.Float64Value()
+		enc.AddFloat64(field.Key, value)
+	case FieldTypeBool:
+		enc.AddBool(field.Key, field.Integer == 1)
+	case FieldTypeTime, FieldTypeTimeFull:
+		value, _ := field.TimeValue()
+		enc.AddTime(field.Key, value)
+	case FieldTypeDuration:
+		enc.AddDuration(field.Key, time.Duration(field.Integer))
+	case FieldTypeError:
+		if value, ok := field.ErrorValue(); ok && value != nil {
+			enc.AddString(field.Key, value.Error())
+		}
+	case FieldTypeContext:
+	case FieldTypeEncrypted:
+		value, _ := field.Value().(EncryptedValue)
+		if value.Ciphertext == nil {
+			enc.AddString(field.Key, value.String())
+
+			return nil
+		}
+
+		return enc.AddObject(field.Key, value)
+	case FieldTypeSecret:
+		if RedactionEnabled() {
+			enc.AddString(field.Key, Redacted)
+
+			return nil
+		}
+
+		return encodeField(Any(field.Key, field.Interface), enc)
+	case FieldTypeLazy:
+		return encodeField(Any(field.Key, field.Value()), enc)
+	case FieldTypeNamespace:
+		fields, _ := field.NamespaceValue()
+
+		return enc.AddObject(field.Key, FieldMarshalerFunc(func(enc FieldEncoder) error { return Encode(fields, enc) }))
+	case FieldTypeObject:
+		if marshaler, ok := field.ObjectValue(); ok && marshaler != nil {
+			return enc.AddObject(field.Key, marshaler)
+		}
+	default:
+		return enc.AddReflected(field.Key, field.Value())
+	}
+
+	return nil
+}
+
+// JSONEncoder is a FieldEncoder that writes the fields as a JSON object.
+type JSONEncoder struct {
+	buf bytes.Buffer
+}
+
+// NewJSONEncoder create a new JSONEncoder.
+func NewJSONEncoder() *JSONEncoder {
+	return &JSONEncoder{}
+}
+
+// Bytes return the JSON object.
+func (e *JSONEncoder) Bytes() []byte {
+	return []byte(e.String())
+}
+
+// String return the JSON object.
+func (e *JSONEncoder) String() string {
+	return "{" + e.buf.String() + "}"
+}
+
+// Reset remove the added fields.
+func (e *JSONEncoder) Reset() {
+	e.buf.Reset()
+}
+
+func (e *JSONEncoder) addKey(key string) {
+	if e.buf.Len() > 0 {
+		e.buf.WriteByte(',')
+	}
+
+	e.buf.WriteString(strconv.Quote(key))
+	e.buf.WriteByte(':')
+}
+
+// AddString add string value.
+func (e *JSONEncoder) AddString(key, value string) {
+	e.addKey(key)
+	e.writeJSON(value)
+}
+
+// AddInt64 add int64 value.
+func (e *JSONEncoder) AddInt64(key string, value int64) {
+	e.addKey(key)
+	e.buf.WriteString(strconv.FormatInt(value, 10))
+}
+
+// AddUint64 add uint64 value.
+func (e *JSONEncoder) AddUint64(key string, value uint64) {
+	e.addKey(key)
+	e.buf.WriteString(strconv.FormatUint(value, 10))
+}
+
+// AddFloat64 add float64 value, NaN and Inf are added as strings.
+func (e *JSONEncoder) AddFloat64(key string, value float64) {
+	e.addKey(key)
+
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		e.buf.WriteString(strconv.Quote(strconv.FormatFloat(value, 'g', -1, 64)))
+
+		return
+	}
+
+	e.buf.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+// AddBool add bool value.
+func (e *JSONEncoder) AddBool(key string, value bool) {
+	e.addKey(key)
+	e.buf.WriteString(strconv.FormatBool(value))
+}
+
+// AddTime add time value in RFC3339Nano format.
+func (e *JSONEncoder) AddTime(key string, value time.Time) {
+	e.AddString(key, value.Format(time.RFC3339Nano))
+}
+
+// AddDuration add duration value in its string format.
+func (e *JSONEncoder) AddDuration(key string, value time.Duration) {
+	e.AddString(key, value.String())
+}
+
+// AddObject add the value as a nested JSON object.
+func (e *JSONEncoder) AddObject(key string, value FieldMarshaler) error {
+	nested := NewJSONEncoder()
+	if err := value.MarshalFields(nested); err != nil {
+		return err
+	}
+
+	e.addKey(key)
+	e.buf.WriteString(nested.String())
+
+	return nil
+}
+
+// AddReflected add the value using json.Marshal, if the value can not be marshaled,
+// it's added as string by fmt.Sprint, so one bad value never loses the other fields.
+func (e *JSONEncoder) AddReflected(key string, value interface{}) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		e.AddString(key, fmt.Sprint(value))
+
+		return nil
+	}
+
+	e.addKey(key)
+	e.buf.Write(encoded)
+
+	return nil
+}
+
+func (e *JSONEncoder) writeJSON(value string) {
+	encoded, _ := json.Marshal(value) // nolint: errchkjson
+
+	e.buf.Write(encoded)
+}
+
+// LogfmtEncoder is a FieldEncoder that writes the fields in logfmt format (key=value),
+// the keys of nested objects are prefixed with the object key and a dot.
+type LogfmtEncoder struct {
+	buf    bytes.Buffer
+	prefix string
+}
+
+// NewLogfmtEncoder create a new LogfmtEncoder.
+func NewLogfmtEncoder() *LogfmtEncoder {
+	return &LogfmtEncoder{}
+}
+
+// Bytes return the logfmt line.
+func (e *LogfmtEncoder) Bytes() []byte {
+	return e.buf.Bytes()
+}
+
+// String return the logfmt line.
+func (e *LogfmtEncoder) String() string {
+	return e.buf.String()
+}
+
+// Reset remove the added fields.
+func (e *LogfmtEncoder) Reset() {
+	e.buf.Reset()
+}
+
+func (e *LogfmtEncoder) add(key, value string) {
+	if e.buf.Len() > 0 {
+		e.buf.WriteByte(' ')
+	}
+
+	e.buf.WriteString(e.prefix + key)
+	e.buf.WriteByte('=')
+	e.buf.WriteString(logfmtValue(value))
+}
+
+// AddString add string value.
+func (e *LogfmtEncoder) AddString(key, value string) { e.add(key, value) }
+
+// AddInt64 add int64 value.
+func (e *LogfmtEncoder) AddInt64(key string, value int64) { e.add(key, strconv.FormatInt(value, 10)) }
+
+// AddUint64 add uint64 value.
+func (e *LogfmtEncoder) AddUint64(key string, value uint64) {
+	e.add(key, strconv.FormatUint(value, 10))
+}
+
+// AddFloat64 add float64 value.
+func (e *LogfmtEncoder) AddFloat64(key string, value float64) {
+	e.add(key, strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+// AddBool add bool value.
+func (e *LogfmtEncoder) AddBool(key string, value bool) { e.add(key, strconv.FormatBool(value)) }
+
+// AddTime add time value in RFC3339Nano format.
+func (e *LogfmtEncoder) AddTime(key string, value time.Time) {
+	e.add(key, value.Format(time.RFC3339Nano))
+}
+
+// AddDuration add duration value in its string format.
+func (e *LogfmtEncoder) AddDuration(key string, value time.Duration) { e.add(key, value.String()) }
+
+// AddObject add the fields of the value with the key as prefix.
+func (e *LogfmtEncoder) AddObject(key string, value FieldMarshaler) error {
+	prefix := e.prefix
+	e.prefix = prefix + key + "."
+
+	defer func() { e.prefix = prefix }()
+
+	return value.MarshalFields(e)
+}
+
+// AddReflected add the value using fmt.Sprint.
+func (e *LogfmtEncoder) AddReflected(key string, value interface{}) error {
+	e.add(key, fmt.Sprint(value))
+
+	return nil
+}
+
+// logfmtValue quote the value if it's empty or contains spaces, quotes, equal signs or control characters.
+func logfmtValue(value string) string {
+	if value == "" {
+		return `""`
+	}
+
+	for _, r := range value {
+		if r == '=' || r == '"' || unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return strconv.Quote(value)
+		}
+	}
+
+	return value
+}
+
+// ConsoleEncoder is a FieldEncoder that writes the fields in human readable format (key: value, ...),
+// nested objects are wrapped with braces.
+type ConsoleEncoder struct {
+	buf bytes.Buffer
+}
+
+// NewConsoleEncoder create a new ConsoleEncoder.
+func NewConsoleEncoder() *ConsoleEncoder {
+	return &ConsoleEncoder{}
+}
+
+// Bytes return the formatted fields.
+func (e *ConsoleEncoder) Bytes() []byte {
+	return e.buf.Bytes()
+}
+
+// String return the formatted fields.
+func (e *ConsoleEncoder) String() string {
+	return e.buf.String()
+}
+
+// Reset remove the added fields.
+func (e *ConsoleEncoder) Reset() {
+	e.buf.Reset()
+}
+
+func (e *ConsoleEncoder) add(key, value string) {
+	if e.buf.Len() > 0 {
+		e.buf.WriteString(", ")
+	}
+
+	e.buf.WriteString(key)
+	e.buf.WriteString(": ")
+	e.buf.WriteString(value)
+}
+
+// AddString add string value.
+func (e *ConsoleEncoder) AddString(key, value string) { e.add(key, value) }
+
+// AddInt64 add int64 value.
+func (e *ConsoleEncoder) AddInt64(key string, value int64) { e.add(key, strconv.FormatInt(value, 10)) }
+
+// AddUint64 add uint64 value.
+func (e *ConsoleEncoder) AddUint64(key string, value uint64) {
+	e.add(key, strconv.FormatUint(value, 10))
+}
+
+// AddFloat64 add float64 value.
+func (e *ConsoleEncoder) AddFloat64(key string, value float64) {
+	e.add(key, strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+// AddBool add bool value.
+func (e *ConsoleEncoder) AddBool(key string, value bool) { e.add(key, strconv.FormatBool(value)) }
+
+// AddTime add time value in RFC3339Nano format.
+func (e *ConsoleEncoder) AddTime(key string, value time.Time) {
+	e.add(key, value.Format(time.RFC3339Nano))
+}
+
+// AddDuration add duration value in its string format.
+func (e *ConsoleEncoder) AddDuration(key string, value time.Duration) { e.add(key, value.String()) }
+
+// AddObject add the fields of the value wrapped with braces.
+func (e *ConsoleEncoder) AddObject(key string, value FieldMarshaler) error {
+	nested := NewConsoleEncoder()
+	if err := value.MarshalFields(nested); err != nil {
+		return err
+	}
+
+	e.add(key, "{"+nested.String()+"}")
+
+	return nil
+}
+
+// AddReflected add the value using fmt.Sprint.
+func (e *ConsoleEncoder) AddReflected(key string, value interface{}) error {
+	e.add(key, fmt.Sprint(value))
+
+	return nil
+}
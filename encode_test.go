@@ -0,0 +1,92 @@
+package errors_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeTestFields() []errors.Field {
+	return []errors.Field{
+		errors.String("name", "mr software"),
+		errors.Int("count", 2),
+		errors.Uint64("size", 3),
+		errors.Float64("ratio", 0.5),
+		errors.Bool("ok", true),
+		errors.Duration("took", time.Second),
+		errors.Time("at", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		errors.NamedContext("ctx", context.Background()),
+		errors.Namespace("db", errors.String("table", "users")),
+	}
+}
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("json encoder, expect json object", func(t *testing.T) {
+		enc := errors.NewJSONEncoder()
+		require.NoError(t, errors.Encode(encodeTestFields(), enc))
+
+		assert.Equal(t,
+			`{"name":"mr software","count":2,"size":3,"ratio":0.5,"ok":true,"took":"1s","at":"2024-01-01T00:00:00Z","db":{"table":"users"}}`,
+			enc.String(),
+		)
+		assert.True(t, json.Valid(enc.Bytes()))
+	})
+
+	t.Run("logfmt encoder, expect logfmt line", func(t *testing.T) {
+		enc := errors.NewLogfmtEncoder()
+		require.NoError(t, errors.Encode(encodeTestFields(), enc))
+
+		assert.Equal(t, `name="mr software" count=2 size=3 ratio=0.5 ok=true took=1s at=2024-01-01T00:00:00Z db.table=users`, enc.String())
+	})
+
+	t.Run("console encoder, expect readable text", func(t *testing.T) {
+		enc := errors.NewConsoleEncoder()
+		require.NoError(t, errors.Encode(encodeTestFields(), enc))
+
+		assert.Equal(t, `name: mr software, count: 2, size: 3, ratio: 0.5, ok: true, took: 1s, at: 2024-01-01T00:00:00Z, db: {table: users}`, enc.String())
+	})
+
+	t.Run("object, error and lazy fields, expect encoded values", func(t *testing.T) {
+		enc := errors.NewJSONEncoder()
+		fields := []errors.Field{
+			errors.Object("user", errors.FieldMarshalerFunc(func(enc errors.FieldEncoder) error {
+				enc.AddInt64("id", 1)
+
+				return nil
+			})),
+			errors.NamedError("cause", fmt.Errorf("failed")),
+			errors.Lazy("lazy", func() interface{} { return 1 }),
+			errors.Reflect("tags", []string{"a"}),
+		}
+		require.NoError(t, errors.Encode(fields, enc))
+
+		assert.Equal(t, `{"user":{"id":1},"cause":"failed","lazy":1,"tags":["a"]}`, enc.String())
+	})
+
+	t.Run("reflected value can not be marshaled, expect the value as string", func(t *testing.T) {
+		enc := errors.NewJSONEncoder()
+		require.NoError(t, errors.Encode([]errors.Field{errors.Reflect("value", complex(1, 2)), errors.Int("id", 1)}, enc))
+
+		assert.Equal(t, `{"value":"(1+2i)","id":1}`, enc.String())
+
+		encoded, err := errors.NewMultiError(errors.New("failure", errors.Reflect("value", complex(1, 2)))).MarshalJSON()
+		require.NoError(t, err)
+		assert.Contains(t, string(encoded), `"value":"(1+2i)"`)
+	})
+
+	t.Run("reset, expect empty encoder", func(t *testing.T) {
+		enc := errors.NewJSONEncoder()
+		enc.AddString("key", "value")
+		enc.Reset()
+
+		assert.Equal(t, "{}", enc.String())
+	})
+}
@@ -0,0 +1,88 @@
+package errors
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sync"
+)
+
+// Encrypter encrypts values of Encrypted fields.
+// the key of the Encrypter should be only known by the audit pipeline, so only it can decrypt the values.
+type Encrypter interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+}
+
+var (
+	encryptersMx sync.RWMutex
+	encrypters   = map[string]Encrypter{}
+)
+
+// RegisterEncrypter register the Encrypter with the key id, used by Encrypted fields.
+func RegisterEncrypter(kid string, encrypter Encrypter) {
+	encryptersMx.Lock()
+	defer encryptersMx.Unlock()
+
+	encrypters[kid] = encrypter
+}
+
+func getEncrypter(kid string) (Encrypter, bool) {
+	encryptersMx.RLock()
+	defer encryptersMx.RUnlock()
+
+	encrypter, ok := encrypters[kid]
+
+	return encrypter, ok
+}
+
+// EncryptedValue is the value of Encrypted fields.
+type EncryptedValue struct {
+	KeyID      string
+	Ciphertext []byte
+}
+
+// String version of EncryptedValue, the ciphertext is never printed.
+func (v EncryptedValue) String() string {
+	if v.Ciphertext == nil {
+		return "[ENCRYPTION FAILED]"
+	}
+
+	return "[ENCRYPTED:" + v.KeyID + "]"
+}
+
+// MarshalFields implements FieldMarshaler, the key id (kid) and the base64 ciphertext (ciphertext) are added,
+// so the value can be decrypted downstream.
+func (v EncryptedValue) MarshalFields(enc FieldEncoder) error {
+	enc.AddString("kid", v.KeyID)
+	enc.AddString("ciphertext", base64.StdEncoding.EncodeToString(v.Ciphertext))
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, it's the same object as MarshalFields.
+func (v EncryptedValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		KeyID      string `json:"kid"`
+		Ciphertext []byte `json:"ciphertext"`
+	}{KeyID: v.KeyID, Ciphertext: v.Ciphertext})
+}
+
+// Encrypted constructs a field that carries the value encrypted by the Encrypter registered with kid.
+// the value is encrypted at construction, if there is no Encrypter for kid or encryption fails,
+// the field carries no ciphertext and the value is dropped.
+func Encrypted(key string, value []byte, kid string) Field {
+	field := Field{Key: key, Type: FieldTypeEncrypted, Str: kid}
+
+	encrypter, ok := getEncrypter(kid)
+	if !ok {
+		return field
+	}
+
+	ciphertext, err := encrypter.Encrypt(value)
+	if err != nil {
+		return field
+	}
+
+	field.Interface = ciphertext
+
+	return field
+}
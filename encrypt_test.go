@@ -0,0 +1,97 @@
+package errors_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type reverseEncrypter struct{}
+
+func (reverseEncrypter) Encrypt(plaintext []byte) ([]byte, error) {
+	ciphertext := make([]byte, len(plaintext))
+	for i := range plaintext {
+		ciphertext[len(plaintext)-1-i] = plaintext[i]
+	}
+
+	return ciphertext, nil
+}
+
+type failingEncrypter struct{}
+
+func (failingEncrypter) Encrypt([]byte) ([]byte, error) { return nil, stdErrors.New("no key") }
+
+func TestEncrypted(t *testing.T) {
+	t.Parallel()
+
+	errors.RegisterEncrypter("test-key", reverseEncrypter{})
+	errors.RegisterEncrypter("test-failing-key", failingEncrypter{})
+
+	t.Run("encrypter is registered, expect ciphertext", func(t *testing.T) {
+		field := errors.Encrypted("card", []byte("1234"), "test-key")
+
+		assert.Equal(t, errors.FieldTypeEncrypted, field.Type)
+		assert.Equal(t, errors.EncryptedValue{KeyID: "test-key", Ciphertext: []byte("4321")}, field.Value())
+		assert.Equal(t, "[card: [ENCRYPTED:test-key]]", fmt.Sprintf("%s", field))
+	})
+
+	t.Run("encrypter is not registered or failed, expect the value to be dropped", func(t *testing.T) {
+		for _, kid := range []string{"test-unknown-key", "test-failing-key"} {
+			field := errors.Encrypted("card", []byte("1234"), kid)
+
+			assert.Nil(t, field.Interface)
+			assert.False(t, bytes.Contains([]byte(fmt.Sprintf("%+v", field)), []byte("1234")))
+			assert.Equal(t, "[ENCRYPTION FAILED]", fmt.Sprint(field.Value()))
+		}
+	})
+	t.Run("encrypted field is encoded, expect ciphertext that can be decrypted", func(t *testing.T) {
+		err := errors.New("payment failed", errors.Encrypted("card", []byte("1234"), "test-key"))
+
+		enc := errors.NewJSONEncoder()
+		require.NoError(t, errors.EncodeError(err, enc))
+
+		recorder := httptest.NewRecorder()
+		require.NoError(t, errors.WriteHTTPError(recorder, err))
+
+		var encoded struct {
+			Card encryptedJSON `json:"card"`
+		}
+		require.NoError(t, json.Unmarshal(enc.Bytes(), &encoded))
+		assert.Equal(t, "1234", encoded.Card.decrypt(t))
+
+		var body struct {
+			Fields struct {
+				Card encryptedJSON `json:"card"`
+			} `json:"fields"`
+		}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Equal(t, "1234", body.Fields.Card.decrypt(t))
+	})
+}
+
+type encryptedJSON struct {
+	KeyID      string `json:"kid"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+func (e encryptedJSON) decrypt(t *testing.T) string {
+	t.Helper()
+
+	assert.Equal(t, "test-key", e.KeyID)
+
+	ciphertext, err := base64.StdEncoding.DecodeString(e.Ciphertext)
+	require.NoError(t, err)
+
+	// reversing is the decryption of reverseEncrypter.
+	plaintext, _ := reverseEncrypter{}.Encrypt(ciphertext)
+
+	return string(plaintext)
+}
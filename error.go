@@ -3,58 +3,265 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Error is an internal error with fields capabilities.
+// it's safe to format or read the fields of an Error while other goroutines call AddFields on it.
 type Error struct {
-	cause  error
-	msg    string
-	fields []Field
+	cause     error
+	msg       string
+	fields    []Field
+	fieldsMx  sync.RWMutex // guards fields, fields are only appended, so a snapshot never changes.
+	omitCause bool
+	sentinel  int32 // set in strict mode if the error is used as target of Is.
+	stack     []uintptr
+	translate bool // msg is a message key, see NewT.
+	args      []interface{}
+	createdAt time.Time
+}
+
+var excludeCause int32
+
+// SetIncludeCause control whether Error() of all errors includes the cause message recursively, it's true by default.
+// disabling it is useful when the logger prints the chain separately.
+func SetIncludeCause(include bool) {
+	var value int32
+	if !include {
+		value = 1
+	}
+
+	atomic.StoreInt32(&excludeCause, value)
+}
+
+// IncludeCause report whether Error() includes the cause message.
+func IncludeCause() bool {
+	return atomic.LoadInt32(&excludeCause) == 0
 }
 
 // New create a new error.
+// New also records the stack trace at the point it was called if enabled (see SetStackDepth).
 func New(msg string, fields ...Field) error {
-	return &Error{msg: msg, fields: fields}
+	return wrap(1, nil, msg, fields) // skip New
 }
 
 // Wrap creates a new error with given cause.
+// Wrap also records the stack trace at the point it was called if enabled (see SetStackDepth).
+// if auto operation is enabled (see SetAutoOperation), the Op of the caller is attached.
 func Wrap(cause error, msg string, fields ...Field) error {
-	return &Error{cause: cause, msg: msg, fields: fields}
+	return wrap(1, cause, msg, fields) // skip Wrap
 }
 
 // Wrapf is like Wrap, but it does format.
 func Wrapf(cause error, format string, args ...interface{}) error {
-	return Wrap(cause, fmt.Sprintf(format, args...))
+	return wrap(1, cause, fmt.Sprintf(format, args...), nil) // skip Wrapf
+}
+
+// WrapIfErr is like Wrap, but it returns nil if cause is nil, so it can be returned unconditionally:
+//
+//	return errors.WrapIfErr(repo.Save(user), "save user")
+func WrapIfErr(cause error, msg string, fields ...Field) error {
+	if cause == nil {
+		return nil
+	}
+
+	return wrap(1, cause, msg, fields) // skip WrapIfErr
+}
+
+// WrapfIfErr is like Wrapf, but it returns nil if cause is nil.
+func WrapfIfErr(cause error, format string, args ...interface{}) error {
+	if cause == nil {
+		return nil
+	}
+
+	return wrap(1, cause, fmt.Sprintf(format, args...), nil) // skip WrapfIfErr
+}
+
+// WithStack annotates err with a stack trace at the point WithStack was called,
+// the stack is recorded even if it's disabled by SetStackDepth.
+// if err is nil, WithStack returns nil.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	depth := GetStackDepth()
+	if depth == StacktraceNone {
+		depth = StacktraceFull
+	}
+
+	return wrapDepth(1, err, "", nil, depth) // skip WithStack
+}
+
+// WithMessage annotates err with a new message, no stack trace is recorded.
+// if err is nil, WithMessage returns nil.
+func WithMessage(err error, msg string, fields ...Field) error {
+	if err == nil {
+		return nil
+	}
+
+	return wrapDepth(1, err, msg, fields, StacktraceNone) // skip WithMessage
+}
+
+// wrap creates the error of all constructors, skip=0 identifies the caller of wrap.
+func wrap(skip int, cause error, msg string, fields []Field) *Error {
+	return wrapDepth(skip+1, cause, msg, fields, GetStackDepth())
+}
+
+// wrapDepth is like wrap, but the stack is captured with the passed depth.
+func wrapDepth(skip int, cause error, msg string, fields []Field, depth StacktraceDepth) *Error {
+	err, noStat := newError(skip+1, cause, msg, fields, depth)
+	stat(err, noStat)
+
+	return err
+}
+
+// wrapOmitCause is like wrap, but Error() of the error returns only msg, see OmitCause.
+func wrapOmitCause(skip int, cause error, msg string, fields []Field) *Error {
+	err, noStat := newError(skip+1, cause, msg, fields, GetStackDepth())
+	err.omitCause = true
+	stat(err, noStat)
+
+	return err
+}
+
+// newError creates the error of wrapDepth without notifying the default Stater,
+// and report whether the default Stater must not be notified (see NoStat).
+func newError(skip int, cause error, msg string, fields []Field, depth StacktraceDepth) (*Error, bool) {
+	fields, noStat := removeNoStat(fields)
+
+	// internal fields are added after the limits, so they are never dropped.
+	if limits := GetFieldLimits(); limits != (FieldLimits{}) {
+		fields = limitFields(nil, fields, limits)
+	}
+
+	if cause != nil && AutoOperationEnabled() && !hasField(fields, KeyOperation) {
+		// use a full slice expression, so we never write to the caller array.
+		fields = append(fields[:len(fields):len(fields)], Operation(callerFunction(skip+1)))
+	}
+
+	if ErrorIDsEnabled() && !hasField(fields, KeyErrorID) && ID(cause) == "" {
+		// use a full slice expression, so we never write to the caller array.
+		fields = append(fields[:len(fields):len(fields)], String(KeyErrorID, newID()))
+	}
+
+	if maxDepth := MaxChainDepth(); maxDepth > 0 {
+		cause = collapseChain(skip+1, cause, maxDepth-1)
+	}
+
+	if StrictEnabled() {
+		checkNewError(skip+1, cause, fields)
+	}
+
+	atomic.AddUint64(&metrics.ErrorsCreated, 1)
+
+	err := &Error{cause: cause, msg: msg, fields: fields, stack: captureStack(skip+1, depth)}
+	if TimestampsEnabled() {
+		err.createdAt = now()
+	}
+
+	return err, noStat
 }
 
 // Errorf formats according to a format specifier and returns the string
 // as a value that satisfies error.
-// Errorf also records the stack trace at the point it was called.
+// Errorf also records the stack trace at the point it was called if enabled (see SetStackDepth).
 func Errorf(format string, args ...interface{}) error {
-	return Wrap(nil, fmt.Sprintf(format, args...))
+	return wrap(1, nil, fmt.Sprintf(format, args...), nil) // skip Errorf
 }
 
 // ErrorfWithFields is like Errorf and also support Field.
 func ErrorfWithFields(format string, args []interface{}, fields ...Field) error {
-	return Wrap(nil, fmt.Sprintf(format, args...), fields...)
+	return wrap(1, nil, fmt.Sprintf(format, args...), fields) // skip ErrorfWithFields
+}
+
+// WithMessageReplace replace only the outermost message of err, the cause and fields are kept.
+// if err is not Error, its message can not be separated from its cause, so it will be wrapped by msg.
+func WithMessageReplace(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+
+	custom, ok := err.(*Error) // nolint: errorlint
+	if !ok {
+		return Wrap(err, msg)
+	}
+
+	replaced := custom.clone()
+	replaced.msg = msg
+	replaced.translate = false
+	replaced.args = nil
+
+	return replaced
+}
+
+// clone return a shallow copy of the error, appending fields to one does not change the other.
+func (e *Error) clone() *Error {
+	return &Error{
+		cause:     e.cause,
+		msg:       e.msg,
+		fields:    e.getFields(),
+		omitCause: e.omitCause,
+		stack:     e.stack,
+		translate: e.translate,
+		args:      e.args,
+		createdAt: e.createdAt,
+	}
+}
+
+// OmitCause return err that its Error() returns only its own message, without the cause message.
+// the cause is still kept and can be retrieved by Unwrap.
+func OmitCause(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	custom, ok := err.(*Error) // nolint: errorlint
+	if !ok {
+		return wrapOmitCause(1, err, err.Error(), nil) // skip OmitCause
+	}
+
+	omitted := custom.clone()
+	omitted.omitCause = true
+
+	return omitted
 }
 
 // Error return error string.
+// if the error has no message (see WithStack), the cause message is returned.
 func (e *Error) Error() string {
-	if e.cause == nil {
-		return e.msg
+	return e.LocalizedError("")
+}
+
+// LocalizedError is like Error, but messages created by NewT are translated to the language (see SetTranslator).
+func (e *Error) LocalizedError(lang string) string {
+	msg := e.message(lang)
+
+	if msg == "" && e.cause != nil && !e.omitCause {
+		return LocalizedError(e.cause, lang)
 	}
 
-	return e.msg + ": " + e.cause.Error()
+	if e.cause == nil || e.omitCause || !IncludeCause() {
+		return msg
+	}
+
+	return msg + ": " + LocalizedError(e.cause, lang)
 }
 
 // Cause return the cause if error.
 func (e *Error) Cause() error { return e.cause }
 
+// StackTrace return the stack trace recorded at creation of the error, empty if no stack is recorded.
+func (e *Error) StackTrace() string { return formatStack(e.stack) }
+
 // Unwrap return the cause if error.
 func (e *Error) Unwrap() error { return e.cause }
 
-// GetError check if the error is Error, create an empty Error if not.
+// GetError check if the error is Error, adopt it if not (see Adopt).
+// calling GetError on an adopted error returns the same adopted error.
 func GetError(err error) (Err *Error) {
 	var custom *Error
 	ok := errors.As(err, &custom)
@@ -62,7 +269,29 @@ func GetError(err error) (Err *Error) {
 		return custom
 	}
 
-	return &Error{msg: err.Error(), cause: err}
+	return adopt(err)
+}
+
+// Adopt wrap err once in an Error with no message of its own, so fields can be added to a plain error
+// while Error() is still the message of err. the adopted error is found by errors.As, so adopting it again
+// or calling GetError on it returns the same error.
+// if err is already an Error, it's returned as is.
+func Adopt(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if custom, ok := err.(*Error); ok { // nolint: errorlint
+		return custom
+	}
+
+	return adopt(err)
+}
+
+// adopt wrap err in an Error with no message, it's not a new error, so it's built without the constructors
+// (no Stater, metrics, id or stack) and lookups like GetError have no side effects.
+func adopt(err error) *Error {
+	return &Error{cause: err}
 }
 
 // Cause return main error.
@@ -93,7 +322,29 @@ func Cause(err error) error {
 
 // Format is implement the fmt.Formatter for Error.
 func (e *Error) Format(state fmt.State, verb rune) {
-	if len(e.fields) == 0 {
+	counter := &countingState{State: state}
+	defer counter.record()
+
+	e.format(counter, verb)
+}
+
+// format is Format without counting the written bytes.
+func (e *Error) format(state fmt.State, verb rune) {
+	if verb == 'v' && state.Flag('+') {
+		e.formatVerbose(state)
+
+		return
+	}
+
+	if verb == 'q' {
+		// quote the %s form, so the output is always a valid Go string literal that strconv.Unquote can read back.
+		fmt.Fprint(state, strconv.Quote(fmt.Sprintf("%s", uncounted(e))))
+
+		return
+	}
+
+	fields := e.getFields()
+	if len(fields) == 0 {
 		fmt.Fprint(state, e.Error())
 
 		return
@@ -101,31 +352,95 @@ func (e *Error) Format(state fmt.State, verb rune) {
 
 	switch verb {
 	case 'v':
-		if state.Flag('+') {
-			fmt.Fprintf(state, "%+v: %+v", e.Error(), e.fields)
-
-			return
-		}
 
 		if state.Flag('#') {
-			fmt.Fprintf(state, "%v: %#v", e.Error(), e.fields)
+			fmt.Fprintf(state, "%v: %#v", e.Error(), fields)
 
 			return
 		}
 
-		fmt.Fprintf(state, "%v: %v", e.Error(), e.fields)
+		fmt.Fprintf(state, "%v: %v", e.Error(), fields)
 	case 's':
-		fmt.Fprintf(state, "%s: %s", e.Error(), e.fields)
-	case 'q':
-		fmt.Fprintf(state, "%q: %q", e.Error(), e.fields)
+		fmt.Fprintf(state, "%s: %s", e.Error(), fields)
+	}
+}
+
+// formatVerbose formats the error for %+v, the fields key/type/value and the stack trace are included.
+func (e *Error) formatVerbose(state fmt.State) {
+	if fields := e.getFields(); len(fields) == 0 {
+		fmt.Fprint(state, e.Error())
+	} else {
+		fmt.Fprintf(state, "%+v: %+v", e.Error(), fields)
+	}
+
+	if !e.createdAt.IsZero() {
+		fmt.Fprintf(state, "\ncreated at: %s", e.createdAt.Format(time.RFC3339Nano))
+	}
+
+	e.formatStacks(state)
+}
+
+// formatStacks formats the stacks of the chain from the innermost one, the innermost stack is formatted fully
+// and the frames of the next ones that are already formatted by the previous stack are elided.
+func (e *Error) formatStacks(state fmt.State) {
+	var layers []*Error
+
+	for err := error(e); err != nil; err = errors.Unwrap(err) {
+		if custom, ok := err.(*Error); ok && len(custom.stack) != 0 { // nolint: errorlint
+			layers = append(layers, custom)
+		}
+	}
+
+	for i := len(layers) - 1; i >= 0; i-- {
+		if i == len(layers)-1 {
+			fmt.Fprintf(state, "\n%s", layers[i].StackTrace())
+
+			continue
+		}
+
+		if layers[i].msg == "" {
+			fmt.Fprint(state, "\n--- wrapped:")
+		} else {
+			fmt.Fprintf(state, "\n--- wrapped by %q:", layers[i].msg)
+		}
+
+		fmt.Fprintf(state, "\n%s", formatStackElided(layers[i].stack, layers[i+1].stack))
 	}
 }
 
 // AddFields to the passed error.
 // passed error must be Error, if not, a new Error will create.
 func AddFields(err error, fields ...Field) error {
-	customError := GetError(err)
-	customError.fields = append(customError.fields, fields...)
+	var customError *Error
+	if !errors.As(err, &customError) {
+		customError = adopt(err)
+	}
+
+	// the error is already notified to the default Stater, so NoStat has no effect here.
+	fields, _ = removeNoStat(fields)
+
+	if StrictEnabled() {
+		checkAddFields(1, customError, fields) // skip AddFields
+	}
+
+	customError.fieldsMx.Lock()
+	customError.fields = limitFields(customError.fields, fields, GetFieldLimits())
+	customError.fieldsMx.Unlock()
 
 	return customError
 }
+
+// withField wrap err in a new layer with no message that has the field, so the getters (e.g. GetCode) find it
+// before the fields of the same key in the chain. err is not changed, so it can be a package-level sentinel.
+// skip=0 identifies the caller of withField.
+func withField(skip int, err error, field Field) error {
+	return wrap(skip+1, err, "", []Field{field})
+}
+
+// getFields return a snapshot of the fields, appending to the snapshot never changes the error.
+func (e *Error) getFields() []Field {
+	e.fieldsMx.RLock()
+	defer e.fieldsMx.RUnlock()
+
+	return e.fields[:len(e.fields):len(e.fields)]
+}
@@ -3,10 +3,14 @@ package errors_test
 import (
 	stdErrors "errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/mrsoftware/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNew(t *testing.T) {
@@ -52,8 +56,47 @@ func TestGetError(t *testing.T) {
 		)
 
 		err := stdErrors.New(msg)
-		willCreateErr := errors.Wrap(err, msg)
+		willCreateErr := errors.Adopt(err)
 		assert.Equal(t, willCreateErr, errors.GetError(err))
+		assert.Equal(t, msg, errors.GetError(err).Error())
+	})
+
+	t.Run("adopted error, expect the same error", func(t *testing.T) {
+		t.Parallel()
+
+		adopted := errors.GetError(stdErrors.New("some message"))
+
+		assert.Same(t, adopted, errors.GetError(adopted))
+		assert.Same(t, adopted, errors.GetError(fmt.Errorf("wrapper: %w", adopted)))
+	})
+}
+
+func TestAdopt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("plain error, expect wrapped once with the same message", func(t *testing.T) {
+		cause := stdErrors.New("some message")
+		adopted := errors.Adopt(cause)
+
+		var custom *errors.Error
+
+		assert.True(t, errors.As(adopted, &custom))
+		assert.Equal(t, "some message", adopted.Error())
+		assert.Equal(t, "some message", fmt.Sprintf("%v", adopted))
+		assert.ErrorIs(t, adopted, cause)
+		assert.Same(t, adopted, errors.Adopt(adopted))
+	})
+
+	t.Run("fields are added to adopted error, expect fields to be kept", func(t *testing.T) {
+		adopted := errors.Adopt(stdErrors.New("some message"))
+		errors.AddFields(adopted, errors.String("key", "value"))
+
+		assert.Equal(t, []errors.Field{errors.String("key", "value")}, errors.GetFields(adopted))
+		assert.Equal(t, "some message: [{Key: key, Value: value}]", fmt.Sprintf("%v", adopted))
+	})
+
+	t.Run("nil error, expect nil", func(t *testing.T) {
+		assert.Nil(t, errors.Adopt(nil))
 	})
 }
 
@@ -77,6 +120,26 @@ func TestWrapf(t *testing.T) {
 	assert.Equal(t, "some message id: 10: cause", err.Error())
 }
 
+func TestWrapIfErr(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cause is nil, expect nil", func(t *testing.T) {
+		assert.Nil(t, errors.WrapIfErr(nil, "some message"))
+		assert.Nil(t, errors.WrapfIfErr(nil, "some message id: %d", 10))
+	})
+
+	t.Run("cause is not nil, expect wrapped error", func(t *testing.T) {
+		cause := stdErrors.New("cause")
+
+		err := errors.WrapIfErr(cause, "some message", errors.String("key", "value"))
+		assert.Equal(t, "some message: cause", err.Error())
+		assert.Equal(t, []errors.Field{errors.String("key", "value")}, errors.GetFields(err))
+
+		err = errors.WrapfIfErr(cause, "some message id: %d", 10)
+		assert.Equal(t, "some message id: 10: cause", err.Error())
+	})
+}
+
 func TestErrorf(t *testing.T) {
 	t.Parallel()
 
@@ -133,7 +196,20 @@ func TestError_Format(t *testing.T) {
 	t.Run("format q", func(t *testing.T) {
 		err := errors.New("some error", errors.String("username", "mrsoftware"))
 
-		assert.Equal(t, "\"some error\": [\"mrsoftware\"]", fmt.Sprintf("%q", err))
+		assert.Equal(t, `"some error: [[username: mrsoftware]]"`, fmt.Sprintf("%q", err))
+	})
+
+	t.Run("format q with quotes and new lines, expect the s format to be read back by unquote", func(t *testing.T) {
+		for _, err := range []error{
+			errors.New("some \"error\""),
+			errors.New("some error", errors.String("query", "name = \"mrsoftware\"\n"), errors.Int("count", 65)),
+			errors.Wrap(errors.New("cause\twith tab"), "some error", errors.Binary("raw", []byte{0, 1})),
+		} {
+			unquoted, unquoteErr := strconv.Unquote(fmt.Sprintf("%q", err))
+
+			assert.NoError(t, unquoteErr)
+			assert.Equal(t, fmt.Sprintf("%s", err), unquoted)
+		}
 	})
 
 	t.Run("format s", func(t *testing.T) {
@@ -168,10 +244,238 @@ func TestAddFields(t *testing.T) {
 		err := stdErrors.New("standard error")
 
 		field := errors.String("code", "value")
-		expect := errors.Wrap(err, err.Error(), field)
+		expect := errors.Adopt(err)
+		errors.AddFields(expect, field)
 
 		withField := errors.AddFields(err, field)
 
 		assert.Equal(t, expect, withField)
+		assert.Equal(t, "standard error", withField.Error())
+	})
+}
+
+func TestWithMessageReplace(t *testing.T) {
+	t.Parallel()
+
+	t.Run("err is nil, expect nil", func(t *testing.T) {
+		assert.Nil(t, errors.WithMessageReplace(nil, "new message"))
+	})
+
+	t.Run("err is Error, expect to replace only the message", func(t *testing.T) {
+		cause := stdErrors.New("cause")
+		field := errors.String("username", "mrsoftware")
+		err := errors.Wrap(cause, "misleading message", field)
+
+		replaced := errors.WithMessageReplace(err, "new message")
+
+		assert.Equal(t, "new message: cause", replaced.Error())
+		assert.Equal(t, []errors.Field{field}, errors.GetFields(replaced))
+		assert.True(t, errors.Is(replaced, cause))
+		assert.Equal(t, "misleading message: cause", err.Error())
+	})
+
+	t.Run("err is not Error, expect to wrap it", func(t *testing.T) {
+		cause := stdErrors.New("cause")
+
+		replaced := errors.WithMessageReplace(cause, "new message")
+
+		assert.Equal(t, "new message: cause", replaced.Error())
+		assert.True(t, errors.Is(replaced, cause))
+	})
+}
+
+func TestOmitCause(t *testing.T) {
+	t.Parallel()
+
+	t.Run("err is nil, expect nil", func(t *testing.T) {
+		assert.Nil(t, errors.OmitCause(nil))
+	})
+
+	t.Run("err is Error, expect only its own message", func(t *testing.T) {
+		cause := stdErrors.New("cause")
+		err := errors.Wrap(cause, "some message")
+
+		omitted := errors.OmitCause(err)
+
+		assert.Equal(t, "some message", omitted.Error())
+		assert.Equal(t, cause, errors.Cause(omitted))
+		assert.Equal(t, "some message: cause", err.Error())
+	})
+
+	t.Run("err is not Error, expect its message", func(t *testing.T) {
+		cause := stdErrors.New("cause")
+
+		omitted := errors.OmitCause(fmt.Errorf("wrapper: %w", cause))
+
+		assert.Equal(t, "wrapper: cause", omitted.Error())
+		assert.True(t, errors.Is(omitted, cause))
+	})
+}
+
+func TestSetIncludeCause(t *testing.T) {
+	errors.SetIncludeCause(false)
+	defer errors.SetIncludeCause(true)
+
+	err := errors.Wrap(errors.New("cause"), "some message")
+
+	assert.False(t, errors.IncludeCause())
+	assert.Equal(t, "some message", err.Error())
+}
+
+func TestSetStackDepth(t *testing.T) {
+	t.Run("stack is disabled, expect no stack trace", func(t *testing.T) {
+		err := errors.New("some error")
+
+		assert.Empty(t, err.(*errors.Error).StackTrace())
+		assert.Equal(t, "some error", fmt.Sprintf("%+v", err))
+	})
+
+	t.Run("stack is enabled, expect stack trace of the caller", func(t *testing.T) {
+		errors.SetStackDepth(errors.StacktraceFull)
+		defer errors.SetStackDepth(errors.StacktraceNone)
+
+		for _, err := range []error{
+			errors.New("some error"),
+			errors.Wrap(stdErrors.New("cause"), "some error"),
+			errors.Wrapf(stdErrors.New("cause"), "some error %d", 1),
+			errors.Errorf("some error %d", 1),
+		} {
+			trace := err.(*errors.Error).StackTrace()
+			assert.True(t, strings.HasPrefix(trace, "github.com/mrsoftware/errors_test.TestSetStackDepth"), trace)
+		}
+
+		err := errors.New("some error", errors.String("username", "mrsoftware"))
+		assert.True(t, strings.HasPrefix(
+			fmt.Sprintf("%+v", err),
+			"some error: [{Key: username, Type: String, Value: mrsoftware}]\ngithub.com/mrsoftware/errors_test.TestSetStackDepth",
+		))
+		assert.Equal(t, "some error: [{Key: username, Value: mrsoftware}]", fmt.Sprintf("%v", err))
+	})
+}
+
+func TestFormat_StackDedup(t *testing.T) {
+	errors.SetStackDepth(errors.StacktraceFull)
+	defer errors.SetStackDepth(errors.StacktraceNone)
+
+	t.Run("several stacks in chain, expect shared frames elided", func(t *testing.T) {
+		err := errors.Wrap(newInnerStackError(), "outer")
+
+		output := fmt.Sprintf("%+v", err)
+		parts := strings.Split(output, "\n--- wrapped by \"outer\":\n")
+		require.Len(t, parts, 2, output)
+
+		assert.True(t, strings.HasPrefix(parts[0], "outer: inner\ngithub.com/mrsoftware/errors_test.newInnerStackError"), parts[0])
+		assert.Contains(t, parts[0], "testing.tRunner")
+		assert.True(t, strings.HasPrefix(parts[1], "github.com/mrsoftware/errors_test.TestFormat_StackDedup.func1"), parts[1])
+		assert.NotContains(t, parts[1], "testing.tRunner")
+		assert.Regexp(t, `\.\.\. \d+ frames elided \.\.\.$`, parts[1])
+	})
+
+	t.Run("only outer stack, expect one stack", func(t *testing.T) {
+		err := errors.Wrap(stdErrors.New("inner"), "outer")
+
+		assert.NotContains(t, fmt.Sprintf("%+v", err), "--- wrapped")
+	})
+}
+
+func newInnerStackError() error {
+	return errors.New("inner")
+}
+
+func TestWithStack(t *testing.T) {
+	t.Run("nil error, expect nil", func(t *testing.T) {
+		assert.Nil(t, errors.WithStack(nil))
+	})
+
+	t.Run("stack is disabled, expect stack trace of the caller anyway", func(t *testing.T) {
+		cause := stdErrors.New("cause")
+		err := errors.WithStack(cause)
+
+		assert.Equal(t, "cause", err.Error())
+		assert.ErrorIs(t, err, cause)
+
+		trace := err.(*errors.Error).StackTrace()
+		assert.True(t, strings.HasPrefix(trace, "github.com/mrsoftware/errors_test.TestWithStack"), trace)
+	})
+}
+
+func TestWithMessage(t *testing.T) {
+	t.Run("nil error, expect nil", func(t *testing.T) {
+		assert.Nil(t, errors.WithMessage(nil, "some message"))
+	})
+
+	t.Run("stack is enabled, expect message without stack trace", func(t *testing.T) {
+		errors.SetStackDepth(errors.StacktraceFull)
+		defer errors.SetStackDepth(errors.StacktraceNone)
+
+		cause := stdErrors.New("cause")
+		err := errors.WithMessage(cause, "some message", errors.String("key", "value"))
+
+		assert.Equal(t, "some message: cause", err.Error())
+		assert.ErrorIs(t, err, cause)
+		assert.Empty(t, err.(*errors.Error).StackTrace())
+		assert.Equal(t, "value", errors.FindFieldInChain("key", err).Str)
+	})
+}
+
+func TestError_Concurrency(t *testing.T) {
+	t.Parallel()
+
+	// these tests are meaningful when run with the race detector: go test -race.
+	t.Run("format and add fields concurrently, expect no race", func(t *testing.T) {
+		err := errors.New("some error", errors.String("key", "value"))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+
+			go func(i int) {
+				defer wg.Done()
+
+				errors.AddFields(err, errors.Int("index", i))
+			}(i)
+
+			go func() {
+				defer wg.Done()
+
+				_ = fmt.Sprintf("%v %+v %s %q %#v", err, err, err, err, err)
+				_ = errors.GetFields(err)
+				_ = errors.GetChainFields(errors.Wrap(err, "wrapper"))
+				_ = errors.FindFieldInChain("index", err)
+			}()
+		}
+
+		wg.Wait()
+
+		assert.Len(t, errors.GetFields(err), 11)
+	})
+
+	t.Run("clone and add fields concurrently, expect clones to not change", func(t *testing.T) {
+		err := errors.New("some error", errors.String("key", "value"))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+
+			go func(i int) {
+				defer wg.Done()
+
+				errors.AddFields(err, errors.Int("index", i))
+			}(i)
+
+			go func() {
+				defer wg.Done()
+
+				replaced := errors.WithMessageReplace(err, "replaced")
+				fields := errors.GetFields(replaced)
+				errors.AddFields(replaced, errors.String("replaced", "true"))
+
+				assert.Len(t, errors.GetFields(replaced), len(fields)+1)
+			}()
+		}
+
+		wg.Wait()
+
+		assert.Len(t, errors.GetFields(err), 11)
 	})
 }
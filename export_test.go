@@ -0,0 +1,9 @@
+package errors
+
+// UnregisterTemplate remove the template of the name from the default registry, so tests can register it again.
+func UnregisterTemplate(name string) {
+	defaultRegistry.mx.Lock()
+	defer defaultRegistry.mx.Unlock()
+
+	delete(defaultRegistry.templates, name)
+}
@@ -2,8 +2,10 @@ package errors
 
 import (
 	"context"
+	stdErr "errors"
 	"fmt"
 	"math"
+	"strconv"
 	"time"
 )
 
@@ -26,22 +28,34 @@ func (f Field) Format(state fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		if state.Flag('+') {
-			fmt.Fprintf(state, "{Key: %s, Type: %s, Value: %+v}", f.Key, f.Type, f.Value())
+			fmt.Fprintf(state, "{Key: %s, Type: %s, Value: %+v}", f.Key, f.Type, uncounted(f.Value()))
 
 			return
 		}
 
 		if state.Flag('#') {
-			fmt.Fprintf(state, "{%s: %#v}", f.Key, f.Value())
+			fmt.Fprintf(state, "{%s: %#v}", f.Key, uncounted(f.Value()))
 
 			return
 		}
 
-		fmt.Fprintf(state, "{Key: %s, Value: %+v}", f.Key, f.Value())
+		if f.Type == FieldTypeNamespace {
+			// nested fields keep the format of the parent.
+			fmt.Fprintf(state, "{Key: %s, Value: %v}", f.Key, uncounted(f.Value()))
+
+			return
+		}
+
+		fmt.Fprintf(state, "{Key: %s, Value: %+v}", f.Key, uncounted(f.Value()))
 	case 's':
-		fmt.Fprintf(state, "[%s: %s]", f.Key, f.Value())
+		fmt.Fprintf(state, "[%s: %s]", f.Key, uncounted(f.Value()))
 	case 'q':
-		fmt.Fprintf(state, "%q", f.Value())
+		value, ok := f.StringValue()
+		if !ok {
+			value = fmt.Sprint(uncounted(f.Value()))
+		}
+
+		fmt.Fprint(state, strconv.Quote(value))
 	}
 }
 
@@ -53,6 +67,20 @@ func (f Field) Value() interface{} {
 	case FieldTypeInt64:
 		return f.Integer
 	case FieldTypeFloat64:
+		return math.Float64frombits(uint64(f.Integer))
+	case FieldTypeInt32:
+		return int32(f.Integer)
+	case FieldTypeInt16:
+		return int16(f.Integer)
+	case FieldTypeInt8:
+		return int8(f.Integer)
+	case FieldTypeUint64:
+		return uint64(f.Integer)
+	case FieldTypeUint32:
+		return uint32(f.Integer)
+	case FieldTypeUintptr:
+		return uintptr(f.Integer)
+	case FieldTypeFloat32:
 		return math.Float32frombits(uint32(f.Integer))
 	case FieldTypeBinary:
 		return f.Interface
@@ -73,11 +101,165 @@ func (f Field) Value() interface{} {
 		}
 
 		return b
+	case FieldTypeEncrypted:
+		ciphertext, _ := f.Interface.([]byte)
+
+		return EncryptedValue{KeyID: f.Str, Ciphertext: ciphertext}
+	case FieldTypeStringer:
+		return fmt.Sprint(f.Interface)
+	case FieldTypeLazy:
+		value, _ := f.Interface.(*lazyValue)
+
+		return value.get()
+	case FieldTypeObject:
+		return marshalObject(f.Interface)
+	case FieldTypeStack:
+		pcs, _ := f.Interface.([]uintptr)
+
+		return formatStack(pcs)
+	case FieldTypeSecret:
+		if RedactionEnabled() {
+			return Redacted
+		}
+
+		return f.Interface
 	default:
 		return f.Interface
 	}
 }
 
+// StringValue return the value of String, ByteString, Stringer and Stack fields.
+func (f Field) StringValue() (string, bool) {
+	switch f.Type { // nolint: exhaustive
+	case FieldTypeString:
+		return f.Str, true
+	case FieldTypeStringer:
+		return fmt.Sprint(f.Interface), true
+	case FieldTypeStack:
+		pcs, _ := f.Interface.([]uintptr)
+
+		return formatStack(pcs), true
+	case FieldTypeByteString:
+		value, ok := f.Interface.([]byte)
+
+		return string(value), ok
+	default:
+		return "", false
+	}
+}
+
+// Int64Value return the value of Int64, Int32, Int16 and Int8 fields.
+func (f Field) Int64Value() (int64, bool) {
+	switch f.Type { // nolint: exhaustive
+	case FieldTypeInt64, FieldTypeInt32, FieldTypeInt16, FieldTypeInt8:
+		return f.Integer, true
+	default:
+		return 0, false
+	}
+}
+
+// Uint64Value return the value of Uint64, Uint32 and Uintptr fields.
+func (f Field) Uint64Value() (uint64, bool) {
+	switch f.Type { // nolint: exhaustive
+	case FieldTypeUint64, FieldTypeUint32, FieldTypeUintptr:
+		return uint64(f.Integer), true
+	default:
+		return 0, false
+	}
+}
+
+// IntValue return the value of Int64, Int32, Int16 and Int8 fields, if the value fits in int.
+func (f Field) IntValue() (int, bool) {
+	value, ok := f.Int64Value()
+	if !ok || int64(int(value)) != value {
+		return 0, false
+	}
+
+	return int(value), true
+}
+
+// Float64Value return the value of Float64 and Float32 fields.
+func (f Field) Float64Value() (float64, bool) {
+	switch f.Type { // nolint: exhaustive
+	case FieldTypeFloat64:
+		return math.Float64frombits(uint64(f.Integer)), true
+	case FieldTypeFloat32:
+		return float64(math.Float32frombits(uint32(f.Integer))), true
+	default:
+		return 0, false
+	}
+}
+
+// BoolValue return the value of Bool fields.
+func (f Field) BoolValue() (bool, bool) {
+	if f.Type != FieldTypeBool {
+		return false, false
+	}
+
+	return f.Integer == 1, true
+}
+
+// TimeValue return the value of Time fields.
+func (f Field) TimeValue() (time.Time, bool) {
+	switch f.Type { // nolint: exhaustive
+	case FieldTypeTime:
+		location, ok := f.Interface.(*time.Location)
+		if !ok {
+			return time.Time{}, false
+		}
+
+		return time.Unix(0, f.Integer).In(location), true
+	case FieldTypeTimeFull:
+		value, ok := f.Interface.(time.Time)
+
+		return value, ok
+	default:
+		return time.Time{}, false
+	}
+}
+
+// DurationValue return the value of Duration fields.
+func (f Field) DurationValue() (time.Duration, bool) {
+	if f.Type != FieldTypeDuration {
+		return 0, false
+	}
+
+	return time.Duration(f.Integer), true
+}
+
+// BinaryValue return the value of Binary and ByteString fields.
+func (f Field) BinaryValue() ([]byte, bool) {
+	if f.Type != FieldTypeBinary && f.Type != FieldTypeByteString {
+		return nil, false
+	}
+
+	value, ok := f.Interface.([]byte)
+
+	return value, ok
+}
+
+// ErrorValue return the value of Error fields.
+func (f Field) ErrorValue() (error, bool) {
+	if f.Type != FieldTypeError {
+		return nil, false
+	}
+
+	value, ok := f.Interface.(error)
+
+	return value, ok
+}
+
+// ContextValue return the value of Context fields.
+func (f Field) ContextValue() (context.Context, bool) {
+	if f.Type != FieldTypeContext {
+		return nil, false
+	}
+
+	value, ok := f.Interface.(context.Context)
+
+	return value, ok
+}
+
 // String version of Field.
 func (f Field) String() string {
 	return fmt.Sprintf("Key: %s, Type: %s, Value: %s", f.Key, f.Type, f.Value())
@@ -130,6 +312,48 @@ const (
 
 	// FieldTypeContext is used for fields that store context.Context.
 	FieldTypeContext
+
+	// FieldTypeEncrypted is used for fields that store encrypted data, see Encrypted.
+	FieldTypeEncrypted
+
+	// FieldTypeSecret is used for fields that store sensitive data, see Secret.
+	FieldTypeSecret
+
+	// FieldTypeNamespace is used for fields that store a group of fields, see Namespace.
+	FieldTypeNamespace
+
+	// FieldTypeInt32 is used for fields that store Int32.
+	FieldTypeInt32
+
+	// FieldTypeInt16 is used for fields that store Int16.
+	FieldTypeInt16
+
+	// FieldTypeInt8 is used for fields that store Int8.
+	FieldTypeInt8
+
+	// FieldTypeUint64 is used for fields that store Uint/Uint64.
+	FieldTypeUint64
+
+	// FieldTypeUint32 is used for fields that store Uint32.
+	FieldTypeUint32
+
+	// FieldTypeUintptr is used for fields that store Uintptr.
+	FieldTypeUintptr
+
+	// FieldTypeFloat32 is used for fields that store Float32.
+	FieldTypeFloat32
+
+	// FieldTypeStringer is used for fields that store fmt.Stringer, see Stringer.
+	FieldTypeStringer
+
+	// FieldTypeLazy is used for fields that store a value function, see Lazy.
+	FieldTypeLazy
+
+	// FieldTypeObject is used for fields that store FieldMarshaler, see Object.
+	FieldTypeObject
+
+	// FieldTypeStack is used for fields that store the program counters of a stacktrace, see LazyStack.
+	FieldTypeStack
 )
 
 // String version of FieldType.
@@ -159,6 +383,34 @@ func (f FieldType) String() string {
 		return "Bool"
 	case FieldTypeContext:
 		return "Context"
+	case FieldTypeEncrypted:
+		return "Encrypted"
+	case FieldTypeSecret:
+		return "Secret"
+	case FieldTypeNamespace:
+		return "Namespace"
+	case FieldTypeInt32:
+		return "Int32"
+	case FieldTypeInt16:
+		return "Int16"
+	case FieldTypeInt8:
+		return "Int8"
+	case FieldTypeUint64:
+		return "Uint64"
+	case FieldTypeUint32:
+		return "Uint32"
+	case FieldTypeUintptr:
+		return "Uintptr"
+	case FieldTypeFloat32:
+		return "Float32"
+	case FieldTypeStringer:
+		return "Stringer"
+	case FieldTypeLazy:
+		return "Lazy"
+	case FieldTypeObject:
+		return "Object"
+	case FieldTypeStack:
+		return "Stack"
 	case FieldTypeUnknown:
 		fallthrough
 	default:
@@ -179,8 +431,24 @@ func Any(key string, val interface{}) Field { // nolint: cyclop
 		return Int(key, value)
 	case int64:
 		return Int64(key, value)
+	case int32:
+		return Int32(key, value)
+	case int16:
+		return Int16(key, value)
+	case int8:
+		return Int8(key, value)
+	case uint:
+		return Uint(key, value)
+	case uint64:
+		return Uint64(key, value)
+	case uint32:
+		return Uint32(key, value)
+	case uintptr:
+		return Uintptr(key, value)
 	case float64:
 		return Float64(key, value)
+	case float32:
+		return Float32(key, value)
 	case []byte:
 		return Binary(key, value)
 	case bool:
@@ -260,6 +528,48 @@ func Float64(key string, val float64) Field {
 	return Field{Key: key, Type: FieldTypeFloat64, Integer: int64(math.Float64bits(val))}
 }
 
+// Int32 constructs a field with the given key and value.
+func Int32(key string, val int32) Field {
+	return Field{Key: key, Type: FieldTypeInt32, Integer: int64(val)}
+}
+
+// Int16 constructs a field with the given key and value.
+func Int16(key string, val int16) Field {
+	return Field{Key: key, Type: FieldTypeInt16, Integer: int64(val)}
+}
+
+// Int8 constructs a field with the given key and value.
+func Int8(key string, val int8) Field {
+	return Field{Key: key, Type: FieldTypeInt8, Integer: int64(val)}
+}
+
+// Uint constructs a field with the given key and value.
+func Uint(key string, val uint) Field {
+	return Uint64(key, uint64(val))
+}
+
+// Uint64 constructs a field with the given key and value.
+func Uint64(key string, val uint64) Field {
+	return Field{Key: key, Type: FieldTypeUint64, Integer: int64(val)}
+}
+
+// Uint32 constructs a field with the given key and value.
+func Uint32(key string, val uint32) Field {
+	return Field{Key: key, Type: FieldTypeUint32, Integer: int64(val)}
+}
+
+// Uintptr constructs a field with the given key and value.
+func Uintptr(key string, val uintptr) Field {
+	return Field{Key: key, Type: FieldTypeUintptr, Integer: int64(val)}
+}
+
+// Float32 constructs a field that carries a float32. The way the
+// floating-point value is represented is encoder-dependent, so marshaling is
+// necessarily lazy.
+func Float32(key string, val float32) Field {
+	return Field{Key: key, Type: FieldTypeFloat32, Integer: int64(math.Float32bits(val))}
+}
+
 // Time constructs a Field with the given key and value. The encoder
 // controls how the time is serialized.
 func Time(key string, val time.Time) Field {
@@ -278,7 +588,7 @@ func Duration(key string, val time.Duration) Field {
 
 // ErrorField is shorthand for the common idiom NamedError("error", err).
 func ErrorField(err error) Field {
-	return NamedError("error", err)
+	return NamedError(KeyError, err)
 }
 
 // NamedError constructs a field that lazily stores err.Error() under the
@@ -294,7 +604,7 @@ func NamedError(key string, err error) Field {
 
 // ContextField is shorthand for the common idiom NamedContext("ctx", ctx).
 func ContextField(ctx context.Context) Field {
-	return NamedContext("ctx", ctx)
+	return NamedContext(KeyContext, ctx)
 }
 
 // NamedContext constructs a field that carries a bool.
@@ -330,6 +640,61 @@ func StackSkipDepth(key string, skip int, depth StacktraceDepth) Field {
 	return String(key, TakeStacktraceDepth(skip+1, depth)) // skip StackSkip
 }
 
+// Caller constructs a field that stores the function, file and line of the caller, see Frame.String.
+// it's a cheap alternative of Stack when a single frame is enough.
+func Caller(key string) Field {
+	return CallerSkip(key, 1) // skip Caller
+}
+
+// CallerSkip is like Caller, but also skips the given number of frames.
+func CallerSkip(key string, skip int) Field {
+	pcs := make([]uintptr, 1)
+	if callers(skip+1, pcs) == 0 { // skip CallerSkip
+		return String(key, "")
+	}
+
+	frames := stackFrames(pcs)
+	if len(frames) == 0 {
+		return String(key, "")
+	}
+
+	return String(key, frames[0].String())
+}
+
+// StackAll constructs a field that stores the stacktraces of all goroutines under provided key,
+// for deadlock and timeout diagnostics. the output can be large, see StackAllSize.
+func StackAll(key string) Field {
+	return StackAllSize(key, 0)
+}
+
+// StackAllSize is like StackAll, but the stacktraces are truncated to size bytes and TruncationMarker is appended,
+// zero size means no limit.
+func StackAllSize(key string, size int) Field {
+	return String(key, takeAllStacktraces(size))
+}
+
+// LazyStack is like Stack, but only the program counters are captured and the stacktrace is
+// formatted when the field value is used, so errors that are handled and never logged are cheaper.
+func LazyStack(key string) Field {
+	return LazyStackSkipDepth(key, 1, StacktraceFull) // skip LazyStack
+}
+
+// LazyStackSkipDepth is like LazyStack with support of skip and StacktraceDepth.
+func LazyStackSkipDepth(key string, skip int, depth StacktraceDepth) Field {
+	return Field{Key: key, Type: FieldTypeStack, Interface: captureStack(skip+1, depth)} // skip LazyStackSkipDepth
+}
+
+// FramesValue return the frames of Stack fields, see LazyStack.
+func (f Field) FramesValue() ([]Frame, bool) {
+	if f.Type != FieldTypeStack {
+		return nil, false
+	}
+
+	pcs, _ := f.Interface.([]uintptr)
+
+	return stackFrames(pcs), true
+}
+
 // IsNilField check of field is nilField.
 func IsNilField(field Field) bool {
 	if field.Type != FieldTypeReflect {
@@ -340,51 +705,84 @@ func IsNilField(field Field) bool {
 }
 
 // GetChainFields finds all filed from error chain.
+// the fields of all members of MultiError (see Join) are included.
 func GetChainFields(err error) []Field {
 	fields := make([]Field, 0)
 
-	for {
-		eErr := GetError(err)
-		fields = append(fields, eErr.fields...)
-
-		if eErr.cause == nil {
-			break
-		}
+	walkChainFields(err, func(field Field) bool {
+		fields = append(fields, field)
 
-		err = eErr.cause
-	}
+		return true
+	})
 
 	return fields
 }
 
 // FindFieldInChain finds requested filed from error chain.
 func FindFieldInChain(key string, err error) Field {
-	for {
-		eErr := GetError(err)
-		for _, field := range eErr.fields {
-			if field.Key == key {
-				return field
-			}
+	found := nilField(key)
+
+	walkChainFields(err, func(field Field) bool {
+		if field.Key != key {
+			return true
 		}
 
-		if eErr.cause == nil {
-			break
+		found = field
+
+		return false
+	})
+
+	return found
+}
+
+// walkChainFields call fn for fields of error chain in order, until fn returns false.
+// members of MultiError and errors that unwrap to multiple errors are walked one by one.
+func walkChainFields(err error, fn func(field Field) bool) bool {
+	for err != nil {
+		switch typed := err.(type) { // nolint: errorlint
+		case *Error:
+			for _, field := range typed.getFields() {
+				if !fn(field) {
+					return false
+				}
+			}
+
+			err = typed.cause
+		case *MultiError:
+			return walkAllChainFields(typed.Errors(), fn)
+		case interface{ Unwrap() []error }:
+			return walkAllChainFields(typed.Unwrap(), fn)
+		default:
+			err = stdErr.Unwrap(err)
 		}
+	}
+
+	return true
+}
 
-		err = eErr.cause
+func walkAllChainFields(errs []error, fn func(field Field) bool) bool {
+	for _, err := range errs {
+		if !walkChainFields(err, fn) {
+			return false
+		}
 	}
 
-	return nilField(key)
+	return true
 }
 
 // GetFields from passed error.
 func GetFields(err error) []Field {
-	return GetError(err).fields
+	var custom *Error
+	if !As(err, &custom) {
+		return nil
+	}
+
+	return custom.getFields()
 }
 
 // GetField find you field based on the key.
 func GetField(err error, key string) Field {
-	for _, field := range GetError(err).fields {
+	for _, field := range GetFields(err) {
 		if field.Key == key {
 			return field
 		}
@@ -2,8 +2,12 @@
 package errors_test
 
 import (
+	"context"
+	stdErrors "errors"
 	"fmt"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/mrsoftware/errors"
 	"github.com/stretchr/testify/assert"
@@ -53,6 +57,11 @@ func TestField_Format(t *testing.T) {
 		assert.Equal(t, "\"mrsoftware\"", fmt.Sprintf("%q", field))
 	})
 
+	t.Run("format q not string value, expect quoted value", func(t *testing.T) {
+		assert.Equal(t, `"65"`, fmt.Sprintf("%q", errors.Int("count", 65)))
+		assert.Equal(t, `"a\"b"`, fmt.Sprintf("%q", errors.ByteString("raw", []byte(`a"b`))))
+	})
+
 	t.Run("format s", func(t *testing.T) {
 		field := errors.String("username", "mrsoftware")
 
@@ -77,3 +86,145 @@ func TestField_Format(t *testing.T) {
 		assert.Equal(t, "{username: \"mrsoftware\"}", fmt.Sprintf("%#v", field))
 	})
 }
+
+func TestGetChainFields_NotCompatibleError(t *testing.T) {
+	err := stdErrors.New("standard error")
+
+	assert.Empty(t, errors.GetChainFields(err))
+	assert.True(t, errors.IsNilField(errors.FindFieldInChain("field1", err)))
+}
+
+func TestField_TypedValues(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	cause := stdErrors.New("cause")
+	ctx := context.Background()
+
+	t.Run("field has the type, expect value", func(t *testing.T) {
+		str, ok := errors.String("key", "value").StringValue()
+		assert.True(t, ok)
+		assert.Equal(t, "value", str)
+
+		str, ok = errors.ByteString("key", []byte("value")).StringValue()
+		assert.True(t, ok)
+		assert.Equal(t, "value", str)
+
+		i64, ok := errors.Int64("key", 10).Int64Value()
+		assert.True(t, ok)
+		assert.Equal(t, int64(10), i64)
+
+		i, ok := errors.Int("key", 10).IntValue()
+		assert.True(t, ok)
+		assert.Equal(t, 10, i)
+
+		f64, ok := errors.Float64("key", 1.5).Float64Value()
+		assert.True(t, ok)
+		assert.Equal(t, 1.5, f64)
+
+		b, ok := errors.Bool("key", true).BoolValue()
+		assert.True(t, ok)
+		assert.True(t, b)
+
+		tm, ok := errors.Time("key", now).TimeValue()
+		assert.True(t, ok)
+		assert.True(t, now.Equal(tm))
+
+		d, ok := errors.Duration("key", time.Second).DurationValue()
+		assert.True(t, ok)
+		assert.Equal(t, time.Second, d)
+
+		bin, ok := errors.Binary("key", []byte{1}).BinaryValue()
+		assert.True(t, ok)
+		assert.Equal(t, []byte{1}, bin)
+
+		e, ok := errors.NamedError("key", cause).ErrorValue()
+		assert.True(t, ok)
+		assert.Equal(t, cause, e)
+
+		c, ok := errors.NamedContext("key", ctx).ContextValue()
+		assert.True(t, ok)
+		assert.Equal(t, ctx, c)
+	})
+
+	t.Run("field has another type, expect zero value and false", func(t *testing.T) {
+		field := errors.Bool("key", true)
+
+		_, ok := field.StringValue()
+		assert.False(t, ok)
+		_, ok = field.Int64Value()
+		assert.False(t, ok)
+		_, ok = field.IntValue()
+		assert.False(t, ok)
+		_, ok = field.Float64Value()
+		assert.False(t, ok)
+		_, ok = errors.String("key", "true").BoolValue()
+		assert.False(t, ok)
+		_, ok = field.TimeValue()
+		assert.False(t, ok)
+		_, ok = field.DurationValue()
+		assert.False(t, ok)
+		_, ok = field.BinaryValue()
+		assert.False(t, ok)
+		_, ok = field.ErrorValue()
+		assert.False(t, ok)
+		_, ok = field.ContextValue()
+		assert.False(t, ok)
+	})
+}
+
+func TestField_Numbers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("numeric fields, expect values to round-trip", func(t *testing.T) {
+		assert.Equal(t, int32(-32), errors.Int32("key", -32).Value())
+		assert.Equal(t, int16(-16), errors.Int16("key", -16).Value())
+		assert.Equal(t, int8(-8), errors.Int8("key", -8).Value())
+		assert.Equal(t, uint64(math.MaxUint64), errors.Uint64("key", math.MaxUint64).Value())
+		assert.Equal(t, uint64(10), errors.Uint("key", 10).Value())
+		assert.Equal(t, uint32(math.MaxUint32), errors.Uint32("key", math.MaxUint32).Value())
+		assert.Equal(t, uintptr(0xff), errors.Uintptr("key", 0xff).Value())
+		assert.Equal(t, float32(1.25), errors.Float32("key", 1.25).Value())
+		assert.Equal(t, 0.1, errors.Float64("key", 0.1).Value())
+	})
+
+	t.Run("any with numeric values, expect typed fields", func(t *testing.T) {
+		assert.Equal(t, errors.FieldTypeInt32, errors.Any("key", int32(1)).Type)
+		assert.Equal(t, errors.FieldTypeUint64, errors.Any("key", uint(1)).Type)
+		assert.Equal(t, errors.FieldTypeUint32, errors.Any("key", uint32(1)).Type)
+		assert.Equal(t, errors.FieldTypeFloat32, errors.Any("key", float32(1)).Type)
+		assert.Equal(t, "Uintptr", errors.Any("key", uintptr(1)).Type.String())
+	})
+
+	t.Run("typed accessors on smaller types, expect widened values", func(t *testing.T) {
+		i64, ok := errors.Int8("key", -8).Int64Value()
+		assert.True(t, ok)
+		assert.Equal(t, int64(-8), i64)
+
+		u64, ok := errors.Uint32("key", 32).Uint64Value()
+		assert.True(t, ok)
+		assert.Equal(t, uint64(32), u64)
+
+		f64, ok := errors.Float32("key", 1.5).Float64Value()
+		assert.True(t, ok)
+		assert.Equal(t, 1.5, f64)
+
+		_, ok = errors.Int64("key", 1).Uint64Value()
+		assert.False(t, ok)
+	})
+}
+
+type joinedErrors []error
+
+func (j joinedErrors) Error() string   { return "joined" }
+func (j joinedErrors) Unwrap() []error { return j }
+
+func TestGetChainFields_MultipleUnwrap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error unwraps to multiple errors, expect fields of all errors", func(t *testing.T) {
+		err := joinedErrors{errors.New("error 1", errors.String("key1", "value1")), errors.New("error 2", errors.String("key2", "value2"))}
+
+		assert.Equal(t, []errors.Field{errors.String("key1", "value1"), errors.String("key2", "value2")}, errors.GetChainFields(err))
+	})
+}
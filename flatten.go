@@ -0,0 +1,41 @@
+package errors
+
+// Flatten walk the full tree of err, the Error chain and members of MultiError,
+// and return the leaves (errors with no cause), useful for reporting every root cause of a failed batch operation.
+func Flatten(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	return flatten(err, nil)
+}
+
+func flatten(err error, leaves []error) []error {
+	for err != nil {
+		switch typed := err.(type) { // nolint: errorlint
+		case *MultiError:
+			return flattenAll(typed.Errors(), leaves)
+		case interface{ Unwrap() []error }:
+			return flattenAll(typed.Unwrap(), leaves)
+		case interface{ Unwrap() error }:
+			cause := typed.Unwrap()
+			if cause == nil {
+				return append(leaves, err)
+			}
+
+			err = cause
+		default:
+			return append(leaves, err)
+		}
+	}
+
+	return leaves
+}
+
+func flattenAll(errs []error, leaves []error) []error {
+	for _, err := range errs {
+		leaves = flatten(err, leaves)
+	}
+
+	return leaves
+}
@@ -0,0 +1,36 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlatten(t *testing.T) {
+	t.Parallel()
+
+	t.Run("mixed tree, expect all leaves in order", func(t *testing.T) {
+		error1 := stdErrors.New("error 1")
+		error2 := errors.New("error 2")
+		error3 := stdErrors.New("error 3")
+		error4 := stdErrors.New("error 4")
+
+		nested := errors.NewMultiError(fmt.Errorf("wrapper: %w", error3), errors.Wrap(error4, "wrapper"))
+		err := errors.Wrap(errors.NewMultiError(errors.Wrap(error1, "wrapper"), error2, nested), "batch")
+
+		assert.Equal(t, []error{error1, error2, error3, error4}, errors.Flatten(err))
+	})
+
+	t.Run("single chain, expect the root cause", func(t *testing.T) {
+		cause := stdErrors.New("cause")
+
+		assert.Equal(t, []error{cause}, errors.Flatten(errors.Wrap(errors.Wrap(cause, "wrapper"), "wrapper")))
+	})
+
+	t.Run("nil error, expect nil", func(t *testing.T) {
+		assert.Nil(t, errors.Flatten(nil))
+	})
+}
@@ -0,0 +1,80 @@
+package errors
+
+import (
+	stdErr "errors"
+	"runtime"
+	"strconv"
+)
+
+// Frame is a frame of a stacktrace.
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// String return the frame as "function (file:line)".
+func (f Frame) String() string {
+	return f.Function + " (" + f.File + ":" + strconv.Itoa(f.Line) + ")"
+}
+
+// Frames return the frames of the stacktrace of the error, the options of SetStackOptions are applied.
+func (e *Error) Frames() []Frame {
+	return stackFrames(e.stack)
+}
+
+// Frames return the frames of the innermost stacktrace of the error chain,
+// it's the closest one to where the error happened. nil is returned if there is no stacktrace.
+func Frames(err error) []Frame {
+	var frames []Frame
+
+	for ; err != nil; err = stdErr.Unwrap(err) {
+		if custom, ok := err.(*Error); ok && len(custom.stack) != 0 { // nolint: errorlint
+			frames = custom.Frames()
+		}
+	}
+
+	return frames
+}
+
+// stackFrames return the frames of the program counters.
+func stackFrames(pcs []uintptr) []Frame {
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	frames := make([]Frame, 0, len(pcs))
+	stack := &stacktrace{pcs: pcs, frames: runtime.CallersFrames(pcs)}
+	options := getStackOptions()
+
+	stack.each(options, func(frame runtime.Frame) {
+		frames = append(frames, Frame{Function: frame.Function, File: options.trim(frame.File), Line: frame.Line})
+	})
+
+	return frames
+}
+
+// EncodeError add the message (KeyMessage), the fields of the chain (see DedupChainFields),
+// the creation time (KeyCreatedAt, see CreatedAt) and the frames of the chain (KeyStack, see Frames) to the encoder.
+// with JSONEncoder, the frames are an array of objects with function, file and line.
+func EncodeError(err error, enc FieldEncoder) error {
+	if err == nil {
+		return nil
+	}
+
+	enc.AddString(KeyMessage, err.Error())
+
+	if encodeErr := Encode(DedupChainFields(err), enc); encodeErr != nil {
+		return encodeErr
+	}
+
+	if createdAt, ok := CreatedAt(err); ok {
+		enc.AddTime(KeyCreatedAt, createdAt)
+	}
+
+	if frames := Frames(err); len(frames) != 0 {
+		return enc.AddReflected(KeyStack, frames)
+	}
+
+	return nil
+}
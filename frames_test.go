@@ -0,0 +1,77 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrames(t *testing.T) {
+	errors.SetStackDepth(errors.StacktraceFull)
+	defer errors.SetStackDepth(errors.StacktraceNone)
+
+	t.Run("error with stack, expect frames of the innermost stack", func(t *testing.T) {
+		inner := newFramesError()
+		err := errors.Wrap(inner, "outer")
+
+		frames := errors.Frames(err)
+		require.NotEmpty(t, frames)
+		assert.Equal(t, "github.com/mrsoftware/errors_test.newFramesError", frames[0].Function)
+		assert.True(t, strings.HasSuffix(frames[0].File, "frames_test.go"))
+		assert.Greater(t, frames[0].Line, 0)
+		assert.Equal(t, errors.GetError(inner).Frames(), frames)
+	})
+
+	t.Run("error without stack, expect nil", func(t *testing.T) {
+		errors.SetStackDepth(errors.StacktraceNone)
+		defer errors.SetStackDepth(errors.StacktraceFull)
+
+		assert.Nil(t, errors.Frames(errors.New("failed")))
+	})
+
+	t.Run("encode error with json encoder, expect frames as array of objects", func(t *testing.T) {
+		enc := errors.NewJSONEncoder()
+		require.NoError(t, errors.EncodeError(errors.Wrap(newFramesError(), "outer", errors.String("id", "1")), enc))
+
+		var body struct {
+			Message string         `json:"message"`
+			ID      string         `json:"id"`
+			Stack   []errors.Frame `json:"stack"`
+		}
+		require.NoError(t, json.Unmarshal(enc.Bytes(), &body))
+
+		assert.Equal(t, "outer: failed", body.Message)
+		assert.Equal(t, "1", body.ID)
+		require.NotEmpty(t, body.Stack)
+		assert.Equal(t, "github.com/mrsoftware/errors_test.newFramesError", body.Stack[0].Function)
+	})
+}
+
+func newFramesError() error {
+	return errors.New("failed")
+}
+
+func TestCaller(t *testing.T) {
+	t.Parallel()
+
+	t.Run("caller field, expect frame of the caller", func(t *testing.T) {
+		field := errors.Caller("caller")
+
+		assert.Equal(t, "caller", field.Key)
+		assert.Regexp(t, `^github\.com/mrsoftware/errors_test\.TestCaller\.func1 \(.*frames_test\.go:\d+\)$`, field.Value())
+	})
+
+	t.Run("caller with skip, expect frame of the caller of the caller", func(t *testing.T) {
+		field := callerOfCaller()
+
+		assert.Regexp(t, `^github\.com/mrsoftware/errors_test\.TestCaller\.func2 \(`, field.Value())
+	})
+}
+
+func callerOfCaller() errors.Field {
+	return errors.CallerSkip("caller", 1)
+}
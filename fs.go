@@ -0,0 +1,55 @@
+package errors
+
+import (
+	"io/fs"
+	"os"
+)
+
+// FromFS classify the os/fs error with a Code and attach path and op fields.
+// fs.ErrNotExist becomes CodeNotFound, fs.ErrPermission becomes CodePermissionDenied,
+// fs.ErrExist becomes CodeAlreadyExists, fs.ErrInvalid becomes CodeInvalidArgument
+// and os.ErrDeadlineExceeded becomes CodeTimeout.
+// if path is empty the path of fs.PathError is used. the message of err is not changed.
+func FromFS(err error, path string) error {
+	if err == nil {
+		return nil
+	}
+
+	fields := make([]Field, 0, 3)
+
+	if code, ok := fsCode(err); ok {
+		fields = append(fields, CodeField(code))
+	}
+
+	var pathErr *fs.PathError
+	if As(err, &pathErr) {
+		if path == "" {
+			path = pathErr.Path
+		}
+
+		fields = append(fields, Operation(pathErr.Op))
+	}
+
+	if path != "" {
+		fields = append(fields, String(KeyPath, path))
+	}
+
+	return wrapOmitCause(1, err, err.Error(), fields) // skip FromFS
+}
+
+func fsCode(err error) (Code, bool) {
+	switch {
+	case Is(err, fs.ErrNotExist):
+		return CodeNotFound, true
+	case Is(err, fs.ErrPermission):
+		return CodePermissionDenied, true
+	case Is(err, fs.ErrExist):
+		return CodeAlreadyExists, true
+	case Is(err, fs.ErrInvalid):
+		return CodeInvalidArgument, true
+	case Is(err, os.ErrDeadlineExceeded):
+		return CodeTimeout, true
+	default:
+		return "", false
+	}
+}
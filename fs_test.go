@@ -0,0 +1,47 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromFS(t *testing.T) {
+	t.Parallel()
+
+	t.Run("err is nil, expect nil", func(t *testing.T) {
+		assert.Nil(t, errors.FromFS(nil, "file"))
+	})
+
+	t.Run("file not exist, expect not found code with path and op", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "not-exist")
+		_, openErr := os.Open(path)
+
+		err := errors.FromFS(openErr, "")
+
+		assert.Equal(t, openErr.Error(), err.Error())
+		assert.True(t, errors.Is(err, fs.ErrNotExist))
+		assert.Equal(t, errors.CodeNotFound, errors.GetCode(err))
+		assert.Equal(t, path, errors.GetField(err, errors.KeyPath).Value())
+		assert.Equal(t, "open", errors.GetField(err, errors.KeyOperation).Value())
+	})
+
+	t.Run("permission denied, expect permission denied code", func(t *testing.T) {
+		err := errors.FromFS(&fs.PathError{Op: "read", Path: "/secret", Err: fs.ErrPermission}, "/other")
+
+		assert.Equal(t, errors.CodePermissionDenied, errors.GetCode(err))
+		assert.Equal(t, "/other", errors.GetField(err, errors.KeyPath).Value())
+	})
+
+	t.Run("unknown error, expect no code", func(t *testing.T) {
+		err := errors.FromFS(stdErrors.New("disk error"), "/data")
+
+		assert.Equal(t, errors.CodeUnknown, errors.GetCode(err))
+		assert.Equal(t, "/data", errors.GetField(err, errors.KeyPath).Value())
+	})
+}
@@ -0,0 +1,119 @@
+package errors
+
+import (
+	"math"
+	"time"
+)
+
+// FieldOf constructs a field with the given key and value, like Any, but the type is statically known.
+func FieldOf[T any](key string, value T) Field {
+	return Any(key, value)
+}
+
+// Get find the value of the key in error chain as T, false is returned if the key is not found
+// or the value is not T. the conversions of the typed accessors (like Field.IntValue) are applied.
+//
+//	id, ok := errors.Get[int](err, "id")
+func Get[T any](err error, key string) (T, bool) {
+	field := FindFieldInChain(key, err)
+	if IsNilField(field) {
+		var zero T
+
+		return zero, false
+	}
+
+	return ValueOf[T](field)
+}
+
+// ValueOf return the value of the field as T, false is returned if the value is not T.
+func ValueOf[T any](field Field) (T, bool) {
+	var (
+		zero  T
+		value interface{}
+		ok    bool
+	)
+
+	switch any(zero).(type) {
+	case int:
+		value, ok = field.IntValue()
+	case int64:
+		value, ok = field.Int64Value()
+	case int32:
+		value, ok = intOf[int32](field)
+	case int16:
+		value, ok = intOf[int16](field)
+	case int8:
+		value, ok = intOf[int8](field)
+	case uint:
+		value, ok = uintOf[uint](field)
+	case uint64:
+		value, ok = uintOf[uint64](field)
+	case uint32:
+		value, ok = uintOf[uint32](field)
+	case uint16:
+		value, ok = uintOf[uint16](field)
+	case uint8:
+		value, ok = uintOf[uint8](field)
+	case uintptr:
+		value, ok = uintOf[uintptr](field)
+	case float64:
+		value, ok = field.Float64Value()
+	case float32:
+		value, ok = float32Of(field)
+	case string:
+		value, ok = field.StringValue()
+	case []byte:
+		value, ok = field.BinaryValue()
+	case time.Time:
+		value, ok = field.TimeValue()
+	default:
+		value, ok = field.Value(), true
+	}
+
+	if !ok {
+		return zero, false
+	}
+
+	typed, ok := value.(T)
+
+	return typed, ok
+}
+
+// intOf return the value of the integer field as T, false is returned if the value does not fit in T.
+// values of other fields are returned as is.
+func intOf[T int8 | int16 | int32](field Field) (interface{}, bool) {
+	value, ok := field.Int64Value()
+	if !ok {
+		return field.Value(), true
+	}
+
+	converted := T(value)
+
+	return converted, int64(converted) == value
+}
+
+// uintOf return the value of the unsigned integer field as T, false is returned if the value does not fit in T.
+// values of other fields are returned as is.
+func uintOf[T uint | uint8 | uint16 | uint32 | uint64 | uintptr](field Field) (interface{}, bool) {
+	value, ok := field.Uint64Value()
+	if !ok {
+		return field.Value(), true
+	}
+
+	converted := T(value)
+
+	return converted, uint64(converted) == value
+}
+
+// float32Of return the value of the float field as float32, false is returned if the value is not a float32.
+// values of other fields are returned as is.
+func float32Of(field Field) (interface{}, bool) {
+	value, ok := field.Float64Value()
+	if !ok {
+		return field.Value(), true
+	}
+
+	converted := float32(value)
+
+	return converted, float64(converted) == value || math.IsNaN(value)
+}
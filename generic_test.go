@@ -0,0 +1,105 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	type user struct{ Username string }
+
+	now := time.Now()
+	err := errors.Wrap(
+		errors.New("cause", errors.FieldOf("user", user{Username: "mrsoftware"}), errors.FieldOf("id", 10)),
+		"some error",
+		errors.FieldOf("ratio", 0.25),
+		errors.FieldOf("name", "mohammad"),
+		errors.FieldOf("at", now),
+		errors.FieldOf("took", time.Second),
+	)
+
+	t.Run("value has the type, expect the value", func(t *testing.T) {
+		id, ok := errors.Get[int](err, "id")
+		assert.True(t, ok)
+		assert.Equal(t, 10, id)
+
+		id64, ok := errors.Get[int64](err, "id")
+		assert.True(t, ok)
+		assert.Equal(t, int64(10), id64)
+
+		ratio, ok := errors.Get[float64](err, "ratio")
+		assert.True(t, ok)
+		assert.Equal(t, 0.25, ratio)
+
+		name, ok := errors.Get[string](err, "name")
+		assert.True(t, ok)
+		assert.Equal(t, "mohammad", name)
+
+		at, ok := errors.Get[time.Time](err, "at")
+		assert.True(t, ok)
+		assert.True(t, now.Equal(at))
+
+		took, ok := errors.Get[time.Duration](err, "took")
+		assert.True(t, ok)
+		assert.Equal(t, time.Second, took)
+
+		u, ok := errors.Get[user](err, "user")
+		assert.True(t, ok)
+		assert.Equal(t, user{Username: "mrsoftware"}, u)
+	})
+
+	t.Run("value has another type or key is missing, expect false", func(t *testing.T) {
+		_, ok := errors.Get[string](err, "id")
+		assert.False(t, ok)
+
+		_, ok = errors.Get[int](err, "missing")
+		assert.False(t, ok)
+
+		_, ok = errors.Get[int](stdErrors.New("plain"), "id")
+		assert.False(t, ok)
+	})
+
+	t.Run("value has a sized numeric type, expect the value converted", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			field errors.Field
+			get   func(field errors.Field) (interface{}, bool)
+			want  interface{}
+			ok    bool
+		}{
+			{"int32", errors.Int32("v", 7), valueOf[int32], int32(7), true},
+			{"int16", errors.Int16("v", 7), valueOf[int16], int16(7), true},
+			{"int8", errors.Int8("v", 7), valueOf[int8], int8(7), true},
+			{"int8 from int", errors.Int("v", 7), valueOf[int8], int8(7), true},
+			{"int8 overflow", errors.Int("v", 300), valueOf[int8], int8(0), false},
+			{"uint", errors.Uint("v", 7), valueOf[uint], uint(7), true},
+			{"uint64", errors.Uint64("v", 7), valueOf[uint64], uint64(7), true},
+			{"uint32", errors.Uint32("v", 7), valueOf[uint32], uint32(7), true},
+			{"uint16", errors.Any("v", uint16(7)), valueOf[uint16], uint16(7), true},
+			{"uint8", errors.Any("v", uint8(7)), valueOf[uint8], uint8(7), true},
+			{"uint8 from uint64", errors.Uint64("v", 7), valueOf[uint8], uint8(7), true},
+			{"uint8 overflow", errors.Uint64("v", 300), valueOf[uint8], uint8(0), false},
+			{"uintptr", errors.Uintptr("v", 7), valueOf[uintptr], uintptr(7), true},
+			{"float32", errors.Float32("v", 0.5), valueOf[float32], float32(0.5), true},
+			{"float32 from float64", errors.Float64("v", 0.5), valueOf[float32], float32(0.5), true},
+			{"float32 loses precision", errors.Float64("v", 0.1), valueOf[float32], float32(0), false},
+			{"uint from string", errors.String("v", "7"), valueOf[uint], uint(0), false},
+		}
+
+		for _, test := range tests {
+			value, ok := test.get(test.field)
+			assert.Equal(t, test.ok, ok, test.name)
+			assert.Equal(t, test.want, value, test.name)
+		}
+	})
+}
+
+func valueOf[T any](field errors.Field) (interface{}, bool) {
+	return errors.ValueOf[T](field)
+}
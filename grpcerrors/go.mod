@@ -0,0 +1,18 @@
+module github.com/mrsoftware/errors/grpcerrors
+
+go 1.21
+
+require (
+	github.com/mrsoftware/errors v0.0.0
+	github.com/stretchr/testify v1.8.4
+	google.golang.org/grpc v1.65.0
+	google.golang.org/protobuf v1.34.2
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.20.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240528184218-531527333157 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,332 @@
+// Package grpcerrors converts between errors.Error and google.golang.org/grpc/status,
+// fields are encoded as a structpb.Struct detail, so structured context crosses RPC boundaries.
+// each field is sent as {"type": ..., "value": ...}, so the type of the field is restored by FromStatus.
+package grpcerrors
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mrsoftware/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	keyType  = "type"
+	keyValue = "value"
+)
+
+var (
+	codesMx     sync.RWMutex
+	toGRPCCodes = map[errors.Code]codes.Code{
+		errors.CodeUnknown:          codes.Unknown,
+		errors.CodeInternal:         codes.Internal,
+		errors.CodeInvalidArgument:  codes.InvalidArgument,
+		errors.CodeNotFound:         codes.NotFound,
+		errors.CodeAlreadyExists:    codes.AlreadyExists,
+		errors.CodePermissionDenied: codes.PermissionDenied,
+		errors.CodeUnauthenticated:  codes.Unauthenticated,
+		errors.CodeUnavailable:      codes.Unavailable,
+		errors.CodeTimeout:          codes.DeadlineExceeded,
+		errors.CodeCanceled:         codes.Canceled,
+	}
+	fromGRPCCodes = reverseCodes(toGRPCCodes)
+)
+
+// RegisterCode map the code to the gRPC code in both directions, registering an existing code replace it.
+func RegisterCode(code errors.Code, grpcCode codes.Code) {
+	codesMx.Lock()
+	defer codesMx.Unlock()
+
+	toGRPCCodes[code] = grpcCode
+	fromGRPCCodes[grpcCode] = code
+}
+
+// Code return the gRPC code of the error, based on its errors.Code.
+func Code(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+
+	codesMx.RLock()
+	defer codesMx.RUnlock()
+
+	if grpcCode, ok := toGRPCCodes[errors.GetCode(err)]; ok {
+		return grpcCode
+	}
+
+	return codes.Unknown
+}
+
+// ToStatus convert the error to gRPC status, the fields of the chain are added as a structpb.Struct detail.
+// repeated keys in the chain are handled by errors.GetDedupPolicy.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+
+	st := status.New(Code(err), err.Error())
+
+	details := &structpb.Struct{Fields: make(map[string]*structpb.Value)}
+
+	for _, field := range errors.DedupChainFields(err) {
+		if value, ok := typedValue(field); ok {
+			details.Fields[field.Key] = value
+		}
+	}
+
+	if len(details.Fields) == 0 {
+		return st
+	}
+
+	withDetails, detailsErr := st.WithDetails(details)
+	if detailsErr != nil {
+		return st
+	}
+
+	return withDetails
+}
+
+// FromStatus convert the gRPC status to error, the fields in the structpb.Struct details are restored.
+// the errors.Code is restored from the fields, or mapped from the gRPC code if there is none.
+// for status with codes.OK, nil is returned.
+func FromStatus(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	fields := make([]errors.Field, 0)
+	hasCode := false
+
+	for _, detail := range st.Details() {
+		details, ok := detail.(*structpb.Struct)
+		if !ok {
+			continue
+		}
+
+		keys := make([]string, 0, len(details.Fields))
+		for key := range details.Fields {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			fields = append(fields, toField(key, details.Fields[key]))
+			hasCode = hasCode || key == errors.KeyCode
+		}
+	}
+
+	if !hasCode {
+		codesMx.RLock()
+		code, ok := fromGRPCCodes[st.Code()]
+		codesMx.RUnlock()
+
+		if !ok {
+			code = errors.CodeUnknown
+		}
+
+		fields = append(fields, errors.CodeField(code))
+	}
+
+	return errors.New(st.Message(), fields...)
+}
+
+// FromError convert the error returned by a gRPC client to error, see FromStatus.
+// if err is not a gRPC status error, it's returned as is.
+func FromError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	return FromStatus(st)
+}
+
+// WithStatus return err with a GRPCStatus method, so gRPC servers return the status of ToStatus.
+func WithStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &statusError{err: err}
+}
+
+type statusError struct {
+	err error
+}
+
+func (e *statusError) Error() string { return e.err.Error() }
+
+func (e *statusError) Unwrap() error { return e.err }
+
+// GRPCStatus is used by gRPC to get the status of the error.
+func (e *statusError) GRPCStatus() *status.Status { return ToStatus(e.err) }
+
+// typedValue convert the field to {"type": ..., "value": ...} structpb value, false if the field can not be sent.
+func typedValue(field errors.Field) (*structpb.Value, bool) {
+	value, ok := fieldValue(field)
+	if !ok {
+		return nil, false
+	}
+
+	return structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+		keyType:  structpb.NewStringValue(field.Type.String()),
+		keyValue: value,
+	}}), true
+}
+
+// fieldValue convert the field to structpb value, false if the field can not be sent.
+func fieldValue(field errors.Field) (*structpb.Value, bool) {
+	switch field.Type { // nolint: exhaustive
+	case errors.FieldTypeContext:
+		return nil, false
+	case errors.FieldTypeString:
+		return structpb.NewStringValue(field.Str), true
+	case errors.FieldTypeInt64:
+		return structpb.NewNumberValue(float64(field.Integer)), true
+	case errors.FieldTypeFloat64:
+		value, _ := field.Float64Value()
+
+		return structpb.NewNumberValue(value), true
+	case errors.FieldTypeBool:
+		value, _ := field.BoolValue()
+
+		return structpb.NewBoolValue(value), true
+	case errors.FieldTypeTime, errors.FieldTypeTimeFull:
+		value, _ := field.TimeValue()
+
+		return structpb.NewStringValue(value.Format(time.RFC3339Nano)), true
+	case errors.FieldTypeDuration:
+		value, _ := field.DurationValue()
+
+		return structpb.NewStringValue(value.String()), true
+	case errors.FieldTypeError:
+		value, ok := field.ErrorValue()
+		if !ok || value == nil {
+			return structpb.NewNullValue(), true
+		}
+
+		return structpb.NewStringValue(value.Error()), true
+	case errors.FieldTypeNamespace:
+		fields, _ := field.NamespaceValue()
+		nested := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(fields))}
+
+		for _, item := range fields {
+			if value, ok := typedValue(item); ok {
+				nested.Fields[item.Key] = value
+			}
+		}
+
+		return structpb.NewStructValue(nested), true
+	default:
+		value, err := structpb.NewValue(field.Value())
+		if err != nil {
+			return structpb.NewStringValue(fmt.Sprint(field.Value())), true
+		}
+
+		return value, true
+	}
+}
+
+// toField convert the structpb value to field, values sent by typedValue are restored to their field type.
+func toField(key string, value *structpb.Value) errors.Field {
+	fieldType, typed, ok := splitTyped(value)
+	if !ok {
+		return untypedField(key, value)
+	}
+
+	switch fieldType {
+	case errors.FieldTypeString.String():
+		return errors.String(key, typed.GetStringValue())
+	case errors.FieldTypeInt64.String(), errors.FieldTypeInt32.String(), errors.FieldTypeInt16.String(), errors.FieldTypeInt8.String():
+		return errors.Int64(key, int64(typed.GetNumberValue()))
+	case errors.FieldTypeFloat64.String(), errors.FieldTypeFloat32.String():
+		return errors.Float64(key, typed.GetNumberValue())
+	case errors.FieldTypeBool.String():
+		return errors.Bool(key, typed.GetBoolValue())
+	case errors.FieldTypeTime.String(), errors.FieldTypeTimeFull.String():
+		if value, err := time.Parse(time.RFC3339Nano, typed.GetStringValue()); err == nil {
+			return errors.Time(key, value)
+		}
+	case errors.FieldTypeDuration.String():
+		if value, err := time.ParseDuration(typed.GetStringValue()); err == nil {
+			return errors.Duration(key, value)
+		}
+	case errors.FieldTypeError.String():
+		if _, ok := typed.GetKind().(*structpb.Value_StringValue); ok {
+			return errors.NamedError(key, stdErrors.New(typed.GetStringValue()))
+		}
+
+		return errors.NamedError(key, nil)
+	case errors.FieldTypeNamespace.String():
+		nested := typed.GetStructValue()
+		if nested == nil {
+			break
+		}
+
+		keys := make([]string, 0, len(nested.Fields))
+		for key := range nested.Fields {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		fields := make([]errors.Field, 0, len(keys))
+		for _, item := range keys {
+			fields = append(fields, toField(item, nested.Fields[item]))
+		}
+
+		return errors.Namespace(key, fields...)
+	}
+
+	return untypedField(key, typed)
+}
+
+// splitTyped return the type and the value of the value sent by typedValue, false if the value is not typed.
+func splitTyped(value *structpb.Value) (string, *structpb.Value, bool) {
+	typed := value.GetStructValue()
+	if typed == nil || len(typed.Fields) != 2 {
+		return "", nil, false
+	}
+
+	fieldType, ok := typed.Fields[keyType].GetKind().(*structpb.Value_StringValue)
+	if !ok {
+		return "", nil, false
+	}
+
+	fieldValue, ok := typed.Fields[keyValue]
+	if !ok {
+		return "", nil, false
+	}
+
+	return fieldType.StringValue, fieldValue, true
+}
+
+// untypedField convert the structpb value to field based on its kind.
+func untypedField(key string, value *structpb.Value) errors.Field {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		return errors.String(key, kind.StringValue)
+	case *structpb.Value_NumberValue:
+		return errors.Float64(key, kind.NumberValue)
+	case *structpb.Value_BoolValue:
+		return errors.Bool(key, kind.BoolValue)
+	default:
+		return errors.Any(key, value.AsInterface())
+	}
+}
+
+func reverseCodes(mapping map[errors.Code]codes.Code) map[codes.Code]errors.Code {
+	reversed := make(map[codes.Code]errors.Code, len(mapping))
+	for code, grpcCode := range mapping {
+		reversed[grpcCode] = code
+	}
+
+	return reversed
+}
@@ -0,0 +1,157 @@
+package grpcerrors_test
+
+import (
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	"github.com/mrsoftware/errors"
+	"github.com/mrsoftware/errors/grpcerrors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestToStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error has code and fields, expect status with code and fields details", func(t *testing.T) {
+		cause := errors.New("user not found", errors.CodeField(errors.CodeNotFound), errors.String("id", "1"))
+		err := errors.Wrap(cause, "find user", errors.Bool("cached", true))
+
+		st := grpcerrors.ToStatus(err)
+
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.Equal(t, "find user: user not found", st.Message())
+		assert.Len(t, st.Details(), 1)
+	})
+
+	t.Run("nil error, expect ok status", func(t *testing.T) {
+		assert.Equal(t, codes.OK, grpcerrors.ToStatus(nil).Code())
+	})
+
+	t.Run("error field with nil error, expect typed null value detail", func(t *testing.T) {
+		err := errors.New("some error", errors.ErrorField(nil))
+
+		var st *status.Status
+		assert.NotPanics(t, func() { st = grpcerrors.ToStatus(err) })
+		assert.Len(t, st.Details(), 1)
+
+		details, ok := st.Details()[0].(*structpb.Struct)
+		assert.True(t, ok)
+		typed := details.Fields[errors.KeyError].GetStructValue()
+		assert.Equal(t, "Error", typed.Fields["type"].GetStringValue())
+		assert.IsType(t, &structpb.Value_NullValue{}, typed.Fields["value"].GetKind())
+	})
+}
+
+func TestFromStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("status from ToStatus, expect code and fields to be restored", func(t *testing.T) {
+		err := errors.New("user not found", errors.CodeField(errors.CodeNotFound), errors.String("id", "1"), errors.Int("attempt", 2))
+
+		restored := grpcerrors.FromStatus(grpcerrors.ToStatus(err))
+
+		assert.Equal(t, "user not found", restored.Error())
+		assert.Equal(t, errors.CodeNotFound, errors.GetCode(restored))
+		assert.Equal(t, "1", errors.FindFieldInChain("id", restored).Str)
+
+		attempt, ok := errors.FindFieldInChain("attempt", restored).IntValue()
+		assert.True(t, ok)
+		assert.Equal(t, 2, attempt)
+	})
+
+	t.Run("status with http status and retry after, expect int and duration to be restored", func(t *testing.T) {
+		err := errors.New("rate limited", errors.HTTPStatusField(429), errors.Duration(errors.KeyRetryAfter, 3*time.Second),
+			errors.Float64("ratio", 0.5), errors.Namespace("db", errors.Int("rows", 7)))
+
+		restored := grpcerrors.FromStatus(grpcerrors.ToStatus(err))
+
+		assert.Equal(t, 429, errors.HTTPStatus(restored))
+
+		retryAfter, ok := errors.RetryAfter(restored)
+		assert.True(t, ok)
+		assert.Equal(t, 3*time.Second, retryAfter)
+
+		ratio, ok := errors.FindFieldInChain("ratio", restored).Float64Value()
+		assert.True(t, ok)
+		assert.Equal(t, 0.5, ratio)
+
+		db, ok := errors.FindFieldInChain("db", restored).NamespaceValue()
+		assert.True(t, ok)
+		assert.Equal(t, []errors.Field{errors.Int64("rows", 7)}, db)
+	})
+
+	t.Run("status with untyped details, expect fields by value kind", func(t *testing.T) {
+		details, err := structpb.NewStruct(map[string]interface{}{"id": "1", "attempt": 2})
+		assert.NoError(t, err)
+
+		st, err := status.New(codes.NotFound, "user not found").WithDetails(details)
+		assert.NoError(t, err)
+
+		restored := grpcerrors.FromStatus(st)
+
+		assert.Equal(t, "1", errors.FindFieldInChain("id", restored).Str)
+
+		attempt, ok := errors.FindFieldInChain("attempt", restored).Float64Value()
+		assert.True(t, ok)
+		assert.Equal(t, float64(2), attempt)
+	})
+
+	t.Run("status without details, expect code to be mapped", func(t *testing.T) {
+		restored := grpcerrors.FromStatus(status.New(codes.DeadlineExceeded, "deadline exceeded"))
+
+		assert.Equal(t, errors.CodeTimeout, errors.GetCode(restored))
+	})
+
+	t.Run("ok status, expect nil", func(t *testing.T) {
+		assert.Nil(t, grpcerrors.FromStatus(status.New(codes.OK, "")))
+	})
+}
+
+func TestFromError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error is not status, expect the error as is", func(t *testing.T) {
+		err := stdErrors.New("some error")
+
+		assert.Equal(t, err, grpcerrors.FromError(err))
+	})
+
+	t.Run("error is status, expect converted error", func(t *testing.T) {
+		err := grpcerrors.FromError(status.Error(codes.InvalidArgument, "invalid id"))
+
+		assert.Equal(t, errors.CodeInvalidArgument, errors.GetCode(err))
+	})
+}
+
+func TestWithStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error with status, expect grpc to find the status", func(t *testing.T) {
+		cause := errors.New("invalid id", errors.CodeField(errors.CodeInvalidArgument))
+		err := grpcerrors.WithStatus(cause)
+
+		st, ok := status.FromError(err)
+
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.ErrorIs(t, err, cause)
+	})
+
+	t.Run("nil error, expect nil", func(t *testing.T) {
+		assert.Nil(t, grpcerrors.WithStatus(nil))
+	})
+}
+
+func TestRegisterCode(t *testing.T) {
+	t.Parallel()
+
+	code := errors.Code("test_grpc_code")
+	grpcerrors.RegisterCode(code, codes.ResourceExhausted)
+
+	assert.Equal(t, codes.ResourceExhausted, grpcerrors.Code(errors.WithCode(stdErrors.New("cause"), code)))
+	assert.Equal(t, code, errors.GetCode(grpcerrors.FromStatus(status.New(codes.ResourceExhausted, "exhausted"))))
+}
@@ -0,0 +1,179 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+var debugHTML int32
+
+// SetDebugHTML enable or disable the HTML rendering of errors.
+// it's disabled by default and must not be enabled in production, since the output contains all fields and stacks.
+func SetDebugHTML(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+
+	atomic.StoreInt32(&debugHTML, value)
+}
+
+// DebugHTMLEnabled report if HTML rendering of errors is enabled.
+func DebugHTMLEnabled() bool {
+	return atomic.LoadInt32(&debugHTML) == 1
+}
+
+// HTML render the error chain as a collapsible view with fields tables and stack frames,
+// for development error pages.
+// if debug HTML is not enabled (see SetDebugHTML), only the escaped error message is returned.
+func HTML(err error) template.HTML {
+	if err == nil {
+		return ""
+	}
+
+	if !DebugHTMLEnabled() {
+		return template.HTML(template.HTMLEscapeString(err.Error())) // nolint: gosec
+	}
+
+	buffer := &bytes.Buffer{}
+	if tmplErr := htmlTemplate.Execute(buffer, newHTMLLayer(err)); tmplErr != nil {
+		return template.HTML(template.HTMLEscapeString(err.Error())) // nolint: gosec
+	}
+
+	return template.HTML(buffer.String()) // nolint: gosec
+}
+
+type htmlLayer struct {
+	Type    string
+	Message string
+	Fields  []htmlField
+	Stack   []htmlFrame
+	Cause   *htmlLayer
+	Errors  []*htmlLayer
+}
+
+type htmlField struct {
+	Key   string
+	Type  string
+	Value string
+	Stack []htmlFrame
+}
+
+type htmlFrame struct {
+	Function string
+	Location string
+}
+
+func newHTMLLayer(err error) *htmlLayer {
+	layer := &htmlLayer{Type: fmt.Sprintf("%T", err), Message: err.Error()}
+
+	var next error
+
+	switch wrapper := err.(type) { // nolint: errorlint
+	case *Error:
+		layer.Message = wrapper.msg
+		layer.Stack = newHTMLFrames(wrapper.Frames())
+		next = wrapper.cause
+
+		for _, field := range wrapper.getFields() {
+			layer.Fields = append(layer.Fields, newHTMLField(field))
+		}
+	case interface{ Unwrap() []error }:
+		errs := wrapper.Unwrap()
+		if _, ok := err.(*MultiError); ok { // nolint: errorlint
+			layer.Message = occurred(len(errs))
+		}
+
+		for _, err := range errs {
+			if err != nil {
+				layer.Errors = append(layer.Errors, newHTMLLayer(err))
+			}
+		}
+	case interface{ Unwrap() error }:
+		next = wrapper.Unwrap()
+	}
+
+	if next != nil {
+		layer.Cause = newHTMLLayer(next)
+	}
+
+	return layer
+}
+
+func newHTMLField(field Field) htmlField {
+	value := fmt.Sprint(field.Value())
+
+	if frames, ok := field.FramesValue(); ok {
+		return htmlField{Key: field.Key, Type: field.Type.String(), Value: value, Stack: newHTMLFrames(frames)}
+	}
+
+	return htmlField{Key: field.Key, Type: field.Type.String(), Value: value, Stack: parseHTMLStack(value)}
+}
+
+// newHTMLFrames return the HTML frames of the frames.
+func newHTMLFrames(frames []Frame) []htmlFrame {
+	if len(frames) == 0 {
+		return nil
+	}
+
+	htmlFrames := make([]htmlFrame, 0, len(frames))
+	for _, frame := range frames {
+		htmlFrames = append(htmlFrames, htmlFrame{Function: frame.Function, Location: frame.File + ":" + strconv.Itoa(frame.Line)})
+	}
+
+	return htmlFrames
+}
+
+// parseHTMLStack parse the value if it's a stacktrace formatted by stackFormatter,
+// it's only used for the fields that store the stacktrace as string (see Stack), others have structured frames.
+func parseHTMLStack(value string) []htmlFrame {
+	if !strings.Contains(value, "\n\t") {
+		return nil
+	}
+
+	lines := strings.Split(value, "\n")
+	if len(lines)%2 != 0 {
+		return nil
+	}
+
+	frames := make([]htmlFrame, 0, len(lines)/2)
+	for i := 0; i < len(lines); i += 2 {
+		if !strings.HasPrefix(lines[i+1], "\t") {
+			return nil
+		}
+
+		frames = append(frames, htmlFrame{Function: lines[i], Location: strings.TrimPrefix(lines[i+1], "\t")})
+	}
+
+	return frames
+}
+
+var htmlTemplate = template.Must(template.New("error").Parse(`<div class="error-chain">
+<style>
+.error-chain details { margin-left: 1em; font-family: sans-serif; }
+.error-chain summary { cursor: pointer; }
+.error-chain .type { color: #888; }
+.error-chain table { border-collapse: collapse; margin: .5em 0; }
+.error-chain td, .error-chain th { border: 1px solid #ddd; padding: 2px 6px; text-align: left; vertical-align: top; }
+.error-chain pre { margin: 0; }
+.error-chain .func { color: #0550ae; }
+.error-chain .file { color: #6e7781; }
+</style>
+{{template "layer" .}}
+</div>
+{{define "layer"}}<details open>
+<summary><strong>{{.Message}}</strong> <span class="type">{{.Type}}</span></summary>
+{{if .Fields}}<table>
+<tr><th>Key</th><th>Type</th><th>Value</th></tr>
+{{range .Fields}}<tr><td>{{.Key}}</td><td>{{.Type}}</td><td>{{if .Stack}}{{template "stack" .Stack}}{{else}}<pre>{{.Value}}</pre>{{end}}</td></tr>
+{{end}}</table>
+{{end}}{{if .Stack}}{{template "stack" .Stack}}
+{{end}}{{range .Errors}}{{template "layer" .}}
+{{end}}{{if .Cause}}{{template "layer" .Cause}}{{end}}</details>{{end}}
+{{define "stack"}}<pre>{{range .}}<span class="func">{{.Function}}</span>
+	<span class="file">{{.Location}}</span>
+{{end}}</pre>{{end}}`))
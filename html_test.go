@@ -0,0 +1,72 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTML(t *testing.T) {
+	t.Run("debug html is disabled, expect only escaped message", func(t *testing.T) {
+		err := errors.New("<b>some error</b>", errors.String("username", "mrsoftware"))
+
+		assert.Equal(t, "&lt;b&gt;some error&lt;/b&gt;", string(errors.HTML(err)))
+	})
+
+	t.Run("debug html is enabled, expect chain with fields and stack", func(t *testing.T) {
+		errors.SetDebugHTML(true)
+		defer errors.SetDebugHTML(false)
+
+		cause := stdErrors.New("<cause>")
+		err := errors.Wrap(cause, "some error", errors.String("username", "mrsoftware"), errors.Stack("stack"))
+
+		html := string(errors.HTML(err))
+
+		assert.Contains(t, html, "<strong>some error</strong>")
+		assert.Contains(t, html, "<td>username</td><td>String</td><td><pre>mrsoftware</pre></td>")
+		assert.Contains(t, html, `<span class="func">github.com/mrsoftware/errors_test.TestHTML`)
+		assert.Contains(t, html, "<strong>&lt;cause&gt;</strong>")
+	})
+
+	t.Run("nil error, expect empty html", func(t *testing.T) {
+		assert.Empty(t, errors.HTML(nil))
+	})
+}
+
+func TestHTML_ErrorStack(t *testing.T) {
+	errors.SetDebugHTML(true)
+	defer errors.SetDebugHTML(false)
+
+	errors.SetStackDepth(errors.StacktraceFull)
+	defer errors.SetStackDepth(errors.StacktraceNone)
+
+	html := string(errors.HTML(errors.New("some error")))
+
+	assert.Contains(t, html, `<span class="func">github.com/mrsoftware/errors_test.TestHTML_ErrorStack`)
+}
+
+func TestHTML_MultiError(t *testing.T) {
+	errors.SetDebugHTML(true)
+	defer errors.SetDebugHTML(false)
+
+	multi := errors.NewMultiError(errors.New("error 1", errors.String("id", "1")), stdErrors.New("<error 2>"))
+	html := string(errors.HTML(errors.Wrap(multi, "some error")))
+
+	assert.Contains(t, html, "<strong>some error</strong>")
+	assert.Contains(t, html, "<strong>2 errors occurred:</strong>")
+	assert.Contains(t, html, "<strong>error 1</strong>")
+	assert.Contains(t, html, "<td>id</td><td>String</td><td><pre>1</pre></td>")
+	assert.Contains(t, html, "<strong>&lt;error 2&gt;</strong>")
+}
+
+func TestHTML_LazyStack(t *testing.T) {
+	errors.SetDebugHTML(true)
+	defer errors.SetDebugHTML(false)
+
+	html := string(errors.HTML(errors.New("some error", errors.LazyStack("stack"))))
+
+	assert.Contains(t, html, `<span class="func">github.com/mrsoftware/errors_test.TestHTML_LazyStack</span>`)
+	assert.Contains(t, html, `html_test.go:`)
+}
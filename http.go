@@ -0,0 +1,179 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	httpStatusesMx sync.RWMutex
+	httpStatuses   = map[Code]int{
+		CodeUnknown:          http.StatusInternalServerError,
+		CodeInternal:         http.StatusInternalServerError,
+		CodeInvalidArgument:  http.StatusBadRequest,
+		CodeNotFound:         http.StatusNotFound,
+		CodeAlreadyExists:    http.StatusConflict,
+		CodePermissionDenied: http.StatusForbidden,
+		CodeUnauthenticated:  http.StatusUnauthorized,
+		CodeUnavailable:      http.StatusServiceUnavailable,
+		CodeTimeout:          http.StatusGatewayTimeout,
+		CodeCanceled:         499, // nolint: gomnd // client closed request.
+	}
+)
+
+// RegisterHTTPStatus set the HTTP status that HTTPStatus return for errors with the code.
+func RegisterHTTPStatus(code Code, status int) {
+	httpStatusesMx.Lock()
+	defer httpStatusesMx.Unlock()
+
+	httpStatuses[code] = status
+}
+
+// HTTPStatusField constructs a field that carries the intended HTTP status.
+func HTTPStatusField(status int) Field {
+	return Int(KeyHTTPStatus, status)
+}
+
+// WithHTTPStatus return the passed error with the intended HTTP status, it overrides the status of the chain
+// and the passed error is not changed.
+func WithHTTPStatus(err error, status int) error {
+	if err == nil {
+		return nil
+	}
+
+	return withField(1, err, HTTPStatusField(status)) // skip WithHTTPStatus
+}
+
+// HTTPStatus return the intended HTTP status of the error.
+// if no status is set in the chain, the status registered for its Code is returned (see RegisterHTTPStatus),
+// and http.StatusInternalServerError if there is none. for nil error http.StatusOK is returned.
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+
+	if status, ok := FindFieldInChain(KeyHTTPStatus, err).IntValue(); ok {
+		return status
+	}
+
+	return codeHTTPStatus(GetCode(err))
+}
+
+// codeHTTPStatus return the HTTP status registered for the code, http.StatusInternalServerError if there is none.
+func codeHTTPStatus(code Code) int {
+	httpStatusesMx.RLock()
+	defer httpStatusesMx.RUnlock()
+
+	if status, ok := httpStatuses[code]; ok {
+		return status
+	}
+
+	return http.StatusInternalServerError
+}
+
+// HTTPErrorBody is the JSON body written by WriteHTTPError.
+type HTTPErrorBody struct {
+	Message   string                 `json:"message"`
+	Code      Code                   `json:"code"`
+	Fields    map[string]interface{} `json:"fields,omitempty"`
+	CreatedAt *time.Time             `json:"created_at,omitempty"`
+}
+
+// NewHTTPErrorBody create the body of the error, fields of the chain are included,
+// repeated keys in the chain are handled by the policy of GetDedupPolicy.
+func NewHTTPErrorBody(err error) HTTPErrorBody {
+	body := HTTPErrorBody{Message: err.Error(), Code: GetCode(err)}
+
+	if createdAt, ok := CreatedAt(err); ok {
+		body.CreatedAt = &createdAt
+	}
+
+	for _, field := range DedupChainFields(err) {
+		if field.Key == KeyCode || field.Key == KeyHTTPStatus {
+			continue
+		}
+
+		value, ok := httpFieldValue(field)
+		if !ok {
+			continue
+		}
+
+		if body.Fields == nil {
+			body.Fields = make(map[string]interface{})
+		}
+
+		body.Fields[field.Key] = value
+	}
+
+	return body
+}
+
+// httpFieldValue return the JSON value of the field, false if the field can not be written.
+func httpFieldValue(field Field) (interface{}, bool) {
+	if fields, ok := field.NamespaceValue(); ok {
+		values := make(map[string]interface{}, len(fields))
+
+		for _, item := range fields {
+			if value, ok := httpFieldValue(item); ok {
+				values[item.Key] = value
+			}
+		}
+
+		return values, true
+	}
+
+	switch value := field.Value().(type) {
+	case context.Context:
+		return nil, false
+	case error:
+		return value.Error(), true
+	case EncryptedValue:
+		if value.Ciphertext == nil {
+			return value.String(), true
+		}
+
+		return value, true
+	default:
+		if _, err := json.Marshal(value); err != nil {
+			return fmt.Sprint(value), true
+		}
+
+		return value, true
+	}
+}
+
+// WriteHTTPError write the error with its fields as a JSON body (see HTTPErrorBody), with the status of HTTPStatus.
+func WriteHTTPError(w http.ResponseWriter, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(HTTPStatus(err))
+
+	return json.NewEncoder(w).Encode(NewHTTPErrorBody(err))
+}
+
+// HTTPHandlerFunc is an http.Handler that can return error,
+// the returned error is written by WriteHTTPError.
+//
+//	mux.Handle("/users", errors.HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+//		user, err := findUser(r.URL.Query().Get("id"))
+//		if err != nil {
+//			return errors.Wrap(err, "find user")
+//		}
+//
+//		return json.NewEncoder(w).Encode(user)
+//	}))
+type HTTPHandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP call f and write the returned error.
+func (f HTTPHandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := f(w, r); err != nil {
+		_ = WriteHTTPError(w, err)
+	}
+}
@@ -0,0 +1,129 @@
+package errors_test
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("status is set, expect the status after wrapping", func(t *testing.T) {
+		err := errors.WithHTTPStatus(errors.New("too many requests"), http.StatusTooManyRequests)
+
+		assert.Equal(t, http.StatusTooManyRequests, errors.HTTPStatus(errors.Wrap(err, "some error")))
+	})
+
+	t.Run("status is not set, expect the status of the code", func(t *testing.T) {
+		err := errors.Wrap(errors.New("cause", errors.CodeField(errors.CodeNotFound)), "some error")
+
+		assert.Equal(t, http.StatusNotFound, errors.HTTPStatus(err))
+	})
+
+	t.Run("custom code is registered, expect the registered status", func(t *testing.T) {
+		code := errors.Code("test_http_status")
+		errors.RegisterHTTPStatus(code, http.StatusTeapot)
+
+		assert.Equal(t, http.StatusTeapot, errors.HTTPStatus(errors.WithCode(stdErrors.New("cause"), code)))
+	})
+
+	t.Run("no status and code, expect internal server error", func(t *testing.T) {
+		assert.Equal(t, http.StatusInternalServerError, errors.HTTPStatus(stdErrors.New("cause")))
+		assert.Equal(t, http.StatusInternalServerError, errors.HTTPStatus(errors.WithCode(stdErrors.New("cause"), "not_registered")))
+	})
+
+	t.Run("status is set again, expect the status overridden", func(t *testing.T) {
+		err := errors.WithHTTPStatus(errors.New("not found"), http.StatusNotFound)
+
+		assert.Equal(t, http.StatusInternalServerError, errors.HTTPStatus(errors.WithHTTPStatus(err, http.StatusInternalServerError)))
+		assert.Equal(t, http.StatusNotFound, errors.HTTPStatus(err))
+	})
+
+	t.Run("status is set on a sentinel many times, expect the sentinel not changed", func(t *testing.T) {
+		sentinel := errors.New("sentinel")
+		for i := 0; i < 10; i++ {
+			err := errors.WithHTTPStatus(sentinel, http.StatusNotFound)
+
+			assert.ErrorIs(t, err, sentinel)
+			assert.Equal(t, http.StatusNotFound, errors.HTTPStatus(err))
+		}
+
+		assert.Empty(t, errors.GetFields(sentinel))
+	})
+
+	t.Run("nil error, expect ok", func(t *testing.T) {
+		assert.Nil(t, errors.WithHTTPStatus(nil, http.StatusNotFound))
+		assert.Equal(t, http.StatusOK, errors.HTTPStatus(nil))
+	})
+}
+
+func TestWriteHTTPError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error has fields, expect json body with fields", func(t *testing.T) {
+		cause := errors.New("user not found", errors.CodeField(errors.CodeNotFound), errors.String("id", "1"))
+		err := errors.Wrap(cause, "find user",
+			errors.String("id", "2"),
+			errors.NamedError("reason", stdErrors.New("no rows")),
+			errors.NamedContext("ctx", context.Background()),
+		)
+
+		recorder := httptest.NewRecorder()
+		require.NoError(t, errors.WriteHTTPError(recorder, err))
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Equal(t, "application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, map[string]interface{}{
+			"message": "find user: user not found",
+			"code":    "not_found",
+			"fields":  map[string]interface{}{"id": "2", "reason": "no rows"},
+		}, body)
+	})
+
+	t.Run("nil error, expect nothing to be written", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		require.NoError(t, errors.WriteHTTPError(recorder, nil))
+
+		assert.Empty(t, recorder.Body.String())
+	})
+}
+
+func TestHTTPHandlerFunc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("handler returns error, expect error to be written", func(t *testing.T) {
+		handler := errors.HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return errors.New("invalid id", errors.CodeField(errors.CodeInvalidArgument))
+		})
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.JSONEq(t, `{"message": "invalid id", "code": "invalid_argument"}`, recorder.Body.String())
+	})
+
+	t.Run("handler returns nil, expect response of handler", func(t *testing.T) {
+		handler := errors.HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(http.StatusNoContent)
+
+			return nil
+		})
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+	})
+}
@@ -0,0 +1,60 @@
+package errors
+
+import "sync"
+
+// Translator render the message of the key and args in the language, lang is empty for Error().
+type Translator func(key string, args []interface{}, lang string) string
+
+var (
+	translatorMx sync.RWMutex
+	translator   Translator
+)
+
+// SetTranslator set the translator of messages created by NewT, nil removes the translator.
+func SetTranslator(fn Translator) {
+	translatorMx.Lock()
+	translator = fn
+	translatorMx.Unlock()
+}
+
+// NewT create a new error with a message key, the message is rendered by the translator (see SetTranslator),
+// if no translator is set, the key is the message.
+// the key is also added as a field, so logs keep the key regardless of the language.
+func NewT(key string, args []interface{}, fields ...Field) error {
+	// use a full slice expression, so we never write to the caller array.
+	err := wrap(1, nil, key, append(fields[:len(fields):len(fields)], String(KeyMessageKey, key))) // skip NewT
+	err.translate = true
+	err.args = args
+
+	return err
+}
+
+// LocalizedError return the message of err, messages created by NewT in the chain are translated to the language.
+func LocalizedError(err error, lang string) string {
+	if err == nil {
+		return ""
+	}
+
+	if custom, ok := err.(*Error); ok { // nolint: errorlint
+		return custom.LocalizedError(lang)
+	}
+
+	return err.Error()
+}
+
+// message return the message of the error in the language.
+func (e *Error) message(lang string) string {
+	if !e.translate {
+		return e.msg
+	}
+
+	translatorMx.RLock()
+	fn := translator
+	translatorMx.RUnlock()
+
+	if fn == nil {
+		return e.msg
+	}
+
+	return fn(e.msg, e.args, lang)
+}
@@ -0,0 +1,45 @@
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewT(t *testing.T) {
+	t.Run("no translator, expect the key as message", func(t *testing.T) {
+		err := errors.NewT("user.not_found", []interface{}{"1"})
+
+		assert.Equal(t, "user.not_found", err.Error())
+		assert.Equal(t, "user.not_found", errors.LocalizedError(err, "fa"))
+		assert.Equal(t, "user.not_found", errors.FindFieldInChain(errors.KeyMessageKey, err).Str)
+	})
+
+	t.Run("translator is set, expect translated message in chain", func(t *testing.T) {
+		errors.SetTranslator(func(key string, args []interface{}, lang string) string {
+			if lang == "fa" {
+				return fmt.Sprintf("کاربر %v پیدا نشد", args...)
+			}
+
+			return fmt.Sprintf("user %v not found", args...)
+		})
+		defer errors.SetTranslator(nil)
+
+		err := errors.Wrap(errors.NewT("user.not_found", []interface{}{"1"}, errors.String("id", "1")), "get profile")
+
+		assert.Equal(t, "get profile: user 1 not found", err.Error())
+		assert.Equal(t, "get profile: کاربر 1 پیدا نشد", errors.LocalizedError(err, "fa"))
+		assert.Equal(t, "user.not_found", errors.FindFieldInChain(errors.KeyMessageKey, err).Str)
+		assert.Equal(t, "1", errors.FindFieldInChain("id", err).Str)
+
+		replaced := errors.WithMessageReplace(errors.NewT("user.not_found", []interface{}{"2"}), "replaced")
+		assert.Equal(t, "replaced", errors.LocalizedError(errors.OmitCause(replaced), "fa"))
+	})
+
+	t.Run("not custom error, expect its message", func(t *testing.T) {
+		assert.Equal(t, "some error", errors.LocalizedError(fmt.Errorf("some error"), "fa"))
+		assert.Empty(t, errors.LocalizedError(nil, "fa"))
+	})
+}
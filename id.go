@@ -0,0 +1,29 @@
+package errors
+
+import "sync/atomic"
+
+var errorIDs int32
+
+// SetErrorIDs control whether New, Wrap and other constructors add a unique ID field to errors (see ID),
+// so a user-visible error reference can be correlated with logs. it's disabled by default.
+// the ID is generated by the ID generator (see SetIDGenerator), only if the cause chain has no ID.
+func SetErrorIDs(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+
+	atomic.StoreInt32(&errorIDs, value)
+}
+
+// ErrorIDsEnabled report whether errors get a unique ID.
+func ErrorIDsEnabled() bool {
+	return atomic.LoadInt32(&errorIDs) == 1
+}
+
+// ID return the unique ID of the error chain, empty if there is no ID.
+func ID(err error) string {
+	id, _ := FindFieldInChain(KeyErrorID, err).StringValue()
+
+	return id
+}
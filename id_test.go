@@ -0,0 +1,42 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestID(t *testing.T) {
+	t.Run("ids are disabled, expect no id", func(t *testing.T) {
+		assert.Empty(t, errors.ID(errors.New("some error")))
+		assert.Empty(t, errors.ID(nil))
+	})
+
+	t.Run("ids are enabled, expect one id for the chain", func(t *testing.T) {
+		counter := 0
+
+		errors.SetErrorIDs(true)
+		errors.SetIDGenerator(func() string {
+			counter++
+
+			return "id-" + strconv.Itoa(counter)
+		})
+
+		defer errors.SetErrorIDs(false)
+		defer errors.SetIDGenerator(nil)
+
+		cause := errors.Wrap(stdErrors.New("cause"), "query user")
+		err := errors.Wrap(cause, "get profile")
+		other := errors.New("other error")
+
+		assert.True(t, errors.ErrorIDsEnabled())
+		assert.Equal(t, "id-1", errors.ID(err))
+		assert.Equal(t, "id-2", errors.ID(other))
+		assert.Empty(t, errors.GetFields(err))
+		assert.Equal(t, "other error: [{Key: error_id, Value: id-2}]", fmt.Sprintf("%v", other))
+	})
+}
@@ -0,0 +1,64 @@
+package errors
+
+import "sync"
+
+var (
+	inheritedKeysMx sync.RWMutex
+	inheritedKeys   = []string{KeyCode, KeySeverity, KeyHTTPStatus}
+)
+
+// InheritKeys add keys to the classification keys that WrapKeep copy to the new layer, KeyCode, KeySeverity and KeyHTTPStatus are inherited by default.
+func InheritKeys(keys ...string) {
+	inheritedKeysMx.Lock()
+	defer inheritedKeysMx.Unlock()
+
+	for _, key := range keys {
+		if !containsKey(inheritedKeys, key) {
+			inheritedKeys = append(inheritedKeys, key)
+		}
+	}
+}
+
+// InheritedKeys return the keys that WrapKeep copy to the new layer.
+func InheritedKeys() []string {
+	inheritedKeysMx.RLock()
+	defer inheritedKeysMx.RUnlock()
+
+	keys := make([]string, len(inheritedKeys))
+	copy(keys, inheritedKeys)
+
+	return keys
+}
+
+// WrapKeep is like Wrap, but the classification fields of the cause (see InheritKeys) are copied to the new layer,
+// so boundary layers can re-message the error and still keep its classification.
+// the value of a key is the first one found in the cause chain, passed fields with the same key override it.
+func WrapKeep(cause error, msg string, fields ...Field) error {
+	if cause == nil {
+		return wrap(1, nil, msg, fields) // skip WrapKeep
+	}
+
+	inherited := make([]Field, 0, len(fields))
+
+	for _, key := range InheritedKeys() {
+		if hasField(fields, key) {
+			continue
+		}
+
+		if field := FindFieldInChain(key, cause); !IsNilField(field) {
+			inherited = append(inherited, field)
+		}
+	}
+
+	return wrap(1, cause, msg, append(inherited, fields...)) // skip WrapKeep
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, item := range keys {
+		if item == key {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,55 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapKeep(t *testing.T) {
+	t.Run("cause has code, expect the code to be copied to the new layer", func(t *testing.T) {
+		cause := errors.Wrap(errors.New("not found", errors.CodeField(errors.CodeNotFound)), "query user")
+		err := errors.WrapKeep(cause, "user is not available")
+
+		assert.Equal(t, "user is not available: query user: not found", err.Error())
+		assert.Equal(t, []errors.Field{errors.CodeField(errors.CodeNotFound)}, errors.GetFields(err))
+	})
+
+	t.Run("code is passed, expect the passed code to override the inherited one", func(t *testing.T) {
+		cause := errors.New("not found", errors.CodeField(errors.CodeNotFound))
+		err := errors.WrapKeep(cause, "some message", errors.CodeField(errors.CodeInternal))
+
+		assert.Equal(t, []errors.Field{errors.CodeField(errors.CodeInternal)}, errors.GetFields(err))
+		assert.Equal(t, errors.CodeInternal, errors.GetCode(err))
+	})
+
+	t.Run("cause has http status, expect the status to be copied", func(t *testing.T) {
+		err := errors.WrapKeep(errors.WithHTTPStatus(errors.New("cause"), 429), "some message")
+
+		assert.Equal(t, []errors.Field{errors.HTTPStatusField(429)}, errors.GetFields(err))
+	})
+
+	t.Run("cause has no classification, expect only passed fields", func(t *testing.T) {
+		err := errors.WrapKeep(stdErrors.New("cause"), "some message", errors.String("key", "value"))
+
+		assert.Equal(t, []errors.Field{errors.String("key", "value")}, errors.GetFields(err))
+	})
+
+	t.Run("custom key is inherited, expect the custom key to be copied", func(t *testing.T) {
+		errors.InheritKeys("inherit_test_team", errors.KeyCode)
+
+		cause := errors.New("cause", errors.String("inherit_test_team", "billing"))
+		err := errors.WrapKeep(cause, "some message")
+
+		assert.Equal(t, []string{errors.KeyCode, errors.KeySeverity, errors.KeyHTTPStatus, "inherit_test_team"}, errors.InheritedKeys()[:4])
+		assert.Equal(t, []errors.Field{errors.String("inherit_test_team", "billing")}, errors.GetFields(err))
+	})
+
+	t.Run("nil cause, expect new error", func(t *testing.T) {
+		err := errors.WrapKeep(nil, "some message")
+
+		assert.Equal(t, "some message", err.Error())
+	})
+}
@@ -0,0 +1,78 @@
+package errors
+
+import "io"
+
+// ReaderWithContext return io.Reader that wraps any error of r (except io.EOF) with the fields,
+// pass Operation field to also name the operation.
+func ReaderWithContext(r io.Reader, fields ...Field) io.Reader {
+	return &reader{reader: r, fields: fields}
+}
+
+// WriterWithContext return io.Writer that wraps any error of w with the fields.
+func WriterWithContext(w io.Writer, fields ...Field) io.Writer {
+	return &writer{writer: w, fields: fields}
+}
+
+// CloserWithContext return io.Closer that wraps any error of c with the fields.
+func CloserWithContext(c io.Closer, fields ...Field) io.Closer {
+	return &closer{closer: c, fields: fields}
+}
+
+// ReadCloserWithContext is like ReaderWithContext and CloserWithContext for io.ReadCloser.
+func ReadCloserWithContext(rc io.ReadCloser, fields ...Field) io.ReadCloser {
+	return struct {
+		io.Reader
+		io.Closer
+	}{ReaderWithContext(rc, fields...), CloserWithContext(rc, fields...)}
+}
+
+// WriteCloserWithContext is like WriterWithContext and CloserWithContext for io.WriteCloser.
+func WriteCloserWithContext(wc io.WriteCloser, fields ...Field) io.WriteCloser {
+	return struct {
+		io.Writer
+		io.Closer
+	}{WriterWithContext(wc, fields...), CloserWithContext(wc, fields...)}
+}
+
+type reader struct {
+	reader io.Reader
+	fields []Field
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if err == nil || err == io.EOF { // nolint: errorlint
+		// io.EOF must be returned as is, callers compare it directly.
+		return n, err
+	}
+
+	return n, Wrap(err, "read", r.fields...)
+}
+
+type writer struct {
+	writer io.Writer
+	fields []Field
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	if err == nil {
+		return n, nil
+	}
+
+	return n, Wrap(err, "write", w.fields...)
+}
+
+type closer struct {
+	closer io.Closer
+	fields []Field
+}
+
+func (c *closer) Close() error {
+	err := c.closer.Close()
+	if err == nil {
+		return nil
+	}
+
+	return Wrap(err, "close", c.fields...)
+}
@@ -0,0 +1,68 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingStream struct{ err error }
+
+func (s failingStream) Read([]byte) (int, error)  { return 0, s.err }
+func (s failingStream) Write([]byte) (int, error) { return 0, s.err }
+func (s failingStream) Close() error              { return s.err }
+
+func TestReaderWithContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reader has no error, expect to get data and io.EOF as is", func(t *testing.T) {
+		r := errors.ReaderWithContext(strings.NewReader("data"), errors.String("filename", "data.txt"))
+
+		data, err := io.ReadAll(r)
+		assert.Nil(t, err)
+		assert.Equal(t, "data", string(data))
+
+		_, err = r.Read(make([]byte, 1))
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("reader failed, expect error with fields", func(t *testing.T) {
+		cause := stdErrors.New("disk error")
+		r := errors.ReaderWithContext(failingStream{err: cause}, errors.String("filename", "data.txt"))
+
+		_, err := r.Read(make([]byte, 1))
+		assert.Equal(t, "read: disk error", err.Error())
+		assert.True(t, errors.Is(err, cause))
+		assert.Equal(t, "data.txt", errors.GetField(err, "filename").Value())
+	})
+}
+
+func TestWriteCloserWithContext(t *testing.T) {
+	t.Parallel()
+
+	cause := stdErrors.New("disk error")
+	wc := errors.WriteCloserWithContext(failingStream{err: cause}, errors.Operation("config.Save"))
+
+	_, err := wc.Write([]byte("data"))
+	assert.Equal(t, "write: disk error", err.Error())
+	assert.Equal(t, "config.Save", errors.GetField(err, errors.KeyOperation).Value())
+
+	err = wc.Close()
+	assert.Equal(t, "close: disk error", err.Error())
+	assert.True(t, errors.Is(err, cause))
+}
+
+func TestReadCloserWithContext(t *testing.T) {
+	t.Parallel()
+
+	cause := stdErrors.New("disk error")
+	rc := errors.ReadCloserWithContext(failingStream{err: cause})
+
+	_, err := rc.Read(make([]byte, 1))
+	assert.Equal(t, "read: disk error", err.Error())
+	assert.Equal(t, "close: disk error", rc.Close().Error())
+}
@@ -0,0 +1,113 @@
+package errors
+
+// Well-known field keys, using them keeps fields consistent across services.
+const (
+	// KeyCode is the field key used to store Code.
+	KeyCode = "code"
+
+	// KeyError is the field key used by ErrorField.
+	KeyError = "error"
+
+	// KeyContext is the field key used by ContextField.
+	KeyContext = "ctx"
+
+	// KeyRequestID is the field key used to store the request id.
+	KeyRequestID = "request_id"
+
+	// KeyUserID is the field key used to store the user id.
+	KeyUserID = "user_id"
+
+	// KeyTraceID is the field key used to store the trace id.
+	KeyTraceID = "trace_id"
+
+	// KeyTenant is the field key used to store the tenant.
+	KeyTenant = "tenant"
+
+	// KeyOperation is the field key used to store the operation, like "pkg.Func".
+	KeyOperation = "op"
+
+	// KeyPath is the field key used to store the file path.
+	KeyPath = "path"
+
+	// KeySeverity is the field key used to store Severity.
+	KeySeverity = "severity"
+
+	// KeyRetryable is the field key used to mark retryable errors, see MarkRetryable.
+	KeyRetryable = "retryable"
+
+	// KeyRetryAfter is the field key used to store the delay before retry, see RetryableAfter.
+	KeyRetryAfter = "retry_after"
+
+	// KeyTags is the field key used to store tags, see WithTags.
+	KeyTags = "tags"
+
+	// KeyTemplate is the field key used to store the name of the Template of the error.
+	KeyTemplate = "template"
+
+	// KeyMessageKey is the field key used to store the message key, see NewT.
+	KeyMessageKey = "message_key"
+
+	// KeyErrorID is the field key used to store the unique ID of the error, see SetErrorIDs.
+	KeyErrorID = "error_id"
+
+	// KeyHTTPStatus is the field key used to store the intended HTTP status.
+	KeyHTTPStatus = "http_status"
+
+	// KeyCollapsedLayers is the field key used to store the number of collapsed layers, see SetMaxChainDepth.
+	KeyCollapsedLayers = "collapsed_layers"
+
+	// KeyTask is the field key used to store the name of the task, see WaitGroup.DoNamed.
+	KeyTask = "task"
+
+	// KeyTaskIndex is the field key used to store the index of the task in its WaitGroup, see WaitGroup.DoNamed.
+	KeyTaskIndex = "task_index"
+
+	// KeyIndex is the field key used to store the index of the input item of a task, see WaitGroup.DoIndexed.
+	KeyIndex = "index"
+
+	// KeyTimeout is the field key used to store the exceeded timeout, see WaitGroupWithTaskTimeout.
+	KeyTimeout = "timeout"
+
+	// KeyAttempts is the field key used to store the number of attempts, see WaitGroup.DoRetry.
+	KeyAttempts = "attempts"
+
+	// KeyPanic is the field key used to store the recovered panic value, see WaitGroupWithPanicRecovery.
+	KeyPanic = "panic"
+
+	// KeyCount is the field key used to store the number of collapsed duplicate errors, see MultiError.Dedup.
+	KeyCount = "count"
+
+	// KeyCreatedAt is the key used to store the creation time of the error, see CreatedAt and EncodeError.
+	KeyCreatedAt = "created_at"
+
+	// KeyStack is the key used to store the stack frames, see EncodeError.
+	KeyStack = "stack"
+
+	// KeyDroppedFields is the field key used to store the number of dropped fields, see SetFieldLimits.
+	KeyDroppedFields = "dropped_fields"
+)
+
+// RequestID constructs a field that carries the request id.
+func RequestID(id string) Field {
+	return String(KeyRequestID, id)
+}
+
+// UserID constructs a field that carries the user id.
+func UserID(id string) Field {
+	return String(KeyUserID, id)
+}
+
+// TraceID constructs a field that carries the trace id.
+func TraceID(id string) Field {
+	return String(KeyTraceID, id)
+}
+
+// Tenant constructs a field that carries the tenant.
+func Tenant(tenant string) Field {
+	return String(KeyTenant, tenant)
+}
+
+// Operation constructs a field that carries the operation name, like "pkg.Func".
+func Operation(op string) Field {
+	return String(KeyOperation, op)
+}
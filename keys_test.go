@@ -0,0 +1,27 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWellKnownFields(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New(
+		"some error",
+		errors.RequestID("req-1"),
+		errors.UserID("user-1"),
+		errors.TraceID("trace-1"),
+		errors.Tenant("tenant-1"),
+		errors.Operation("store.Query"),
+	)
+
+	assert.Equal(t, "req-1", errors.GetField(err, errors.KeyRequestID).Value())
+	assert.Equal(t, "user-1", errors.GetField(err, errors.KeyUserID).Value())
+	assert.Equal(t, "trace-1", errors.GetField(err, errors.KeyTraceID).Value())
+	assert.Equal(t, "tenant-1", errors.GetField(err, errors.KeyTenant).Value())
+	assert.Equal(t, "store.Query", errors.GetField(err, errors.KeyOperation).Value())
+}
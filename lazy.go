@@ -0,0 +1,36 @@
+package errors
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Stringer constructs a field with the given key and the output of the value's
+// String method. The String method is only called when the field value is used,
+// e.g. when the error is formatted or serialized.
+func Stringer(key string, val fmt.Stringer) Field {
+	return Field{Key: key, Type: FieldTypeStringer, Interface: val}
+}
+
+// Lazy constructs a field with the given key and the value returned by fn.
+// fn is called once, the first time the field value is used, so expensive values
+// are not computed for errors that are swallowed.
+func Lazy(key string, fn func() interface{}) Field {
+	return Field{Key: key, Type: FieldTypeLazy, Interface: &lazyValue{fn: fn}}
+}
+
+type lazyValue struct {
+	once  sync.Once
+	fn    func() interface{}
+	value interface{}
+}
+
+func (l *lazyValue) get() interface{} {
+	if l == nil || l.fn == nil {
+		return nil
+	}
+
+	l.once.Do(func() { l.value = l.fn() })
+
+	return l.value
+}
@@ -0,0 +1,94 @@
+package errors_test
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stringer field, expect the string value", func(t *testing.T) {
+		field := errors.Stringer("ip", net.IPv4(127, 0, 0, 1))
+
+		assert.Equal(t, "127.0.0.1", field.Value())
+		assert.Equal(t, "[ip: 127.0.0.1]", fmt.Sprintf("%s", field))
+
+		value, ok := field.StringValue()
+		assert.True(t, ok)
+		assert.Equal(t, "127.0.0.1", value)
+	})
+
+	t.Run("nil stringer, expect nil text", func(t *testing.T) {
+		var ip *net.TCPAddr
+
+		assert.Equal(t, "<nil>", errors.Stringer("addr", ip).Value())
+	})
+}
+
+func TestLazy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error is not formatted, expect function is not called", func(t *testing.T) {
+		calls := 0
+		_ = errors.New("failed", errors.Lazy("dump", func() interface{} {
+			calls++
+
+			return "expensive"
+		}))
+
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("error is formatted, expect function is called once", func(t *testing.T) {
+		calls := 0
+		err := errors.New("failed", errors.Lazy("dump", func() interface{} {
+			calls++
+
+			return "expensive"
+		}))
+
+		assert.Equal(t, "failed: [{Key: dump, Value: expensive}]", fmt.Sprintf("%v", err))
+		assert.Equal(t, "expensive", errors.FindFieldInChain("dump", err).Value())
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("nil function, expect nil value", func(t *testing.T) {
+		assert.Nil(t, errors.Lazy("dump", nil).Value())
+	})
+}
+
+func TestLazyStack(t *testing.T) {
+	t.Parallel()
+
+	t.Run("lazy stack field, expect stack formatted on use", func(t *testing.T) {
+		field := errors.LazyStack("stack")
+
+		assert.Equal(t, errors.FieldTypeStack, field.Type)
+		assert.True(t, strings.HasPrefix(field.Value().(string), "github.com/mrsoftware/errors_test.TestLazyStack.func1\n"), field.Value())
+
+		value, ok := field.StringValue()
+		assert.True(t, ok)
+		assert.Equal(t, field.Value(), value)
+
+		frames, ok := field.FramesValue()
+		assert.True(t, ok)
+		assert.Equal(t, "github.com/mrsoftware/errors_test.TestLazyStack.func1", frames[0].Function)
+	})
+
+	t.Run("first frame only, expect one frame", func(t *testing.T) {
+		frames, ok := errors.LazyStackSkipDepth("stack", 0, errors.StacktraceFirst).FramesValue()
+		assert.True(t, ok)
+		assert.Len(t, frames, 1)
+	})
+
+	t.Run("not stack field, expect false", func(t *testing.T) {
+		_, ok := errors.String("stack", "").FramesValue()
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,129 @@
+package errors
+
+import (
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// TruncationMarker is appended to String, ByteString and Binary values that are truncated, see SetFieldLimits.
+const TruncationMarker = "...[truncated]"
+
+// FieldLimits define the limits of the fields of an error, zero means no limit.
+type FieldLimits struct {
+	// MaxFields is the max number of fields of each error layer, the rest are dropped
+	// and the number of dropped fields is stored in KeyDroppedFields field.
+	MaxFields int
+
+	// MaxValueSize is the max size in bytes of String, ByteString and Binary values,
+	// longer values are truncated and TruncationMarker is appended.
+	MaxValueSize int
+}
+
+var (
+	maxFields    int32
+	maxValueSize int32
+)
+
+// SetFieldLimits set the limits that New, Wrap, AddFields and other constructors apply to the fields,
+// there is no limit by default.
+func SetFieldLimits(limits FieldLimits) {
+	atomic.StoreInt32(&maxFields, int32(limits.MaxFields))
+	atomic.StoreInt32(&maxValueSize, int32(limits.MaxValueSize))
+}
+
+// GetFieldLimits return the limits that constructors apply to the fields.
+func GetFieldLimits() FieldLimits {
+	return FieldLimits{MaxFields: int(atomic.LoadInt32(&maxFields)), MaxValueSize: int(atomic.LoadInt32(&maxValueSize))}
+}
+
+// WithFieldLimits return err that the limits are applied to its own fields, the cause is not changed.
+// if err is not Error, it's returned as is.
+func WithFieldLimits(err error, limits FieldLimits) error {
+	custom, ok := err.(*Error) // nolint: errorlint
+	if !ok {
+		return err
+	}
+
+	limited := custom.clone()
+	limited.fields = limitFields(nil, limited.fields, limits)
+
+	return limited
+}
+
+// limitFields return existing fields with the new fields appended, with the limits applied to the new fields.
+func limitFields(existing []Field, fields []Field, limits FieldLimits) []Field {
+	if limits.MaxFields <= 0 && limits.MaxValueSize <= 0 {
+		return append(existing, fields...)
+	}
+
+	limited := make([]Field, 0, len(existing)+len(fields)+1)
+	dropped := 0
+
+	// the dropped fields field of existing fields is replaced by a new one at the end.
+	for _, field := range existing {
+		if field.Key == KeyDroppedFields && field.Type == FieldTypeInt64 {
+			dropped += int(field.Integer)
+
+			continue
+		}
+
+		limited = append(limited, field)
+	}
+
+	if limits.MaxFields > 0 {
+		available := limits.MaxFields - len(limited)
+		if available < 0 {
+			available = 0
+		}
+
+		if len(fields) > available {
+			dropped += len(fields) - available
+			fields = fields[:available]
+		}
+	}
+
+	for _, field := range fields {
+		limited = append(limited, truncateField(field, limits.MaxValueSize))
+	}
+
+	if dropped > 0 {
+		limited = append(limited, Int(KeyDroppedFields, dropped))
+	}
+
+	return limited
+}
+
+func truncateField(field Field, size int) Field {
+	if size <= 0 {
+		return field
+	}
+
+	switch field.Type { // nolint: exhaustive
+	case FieldTypeString:
+		if len(field.Str) > size {
+			field.Str = truncateString(field.Str, size) + TruncationMarker
+		}
+	case FieldTypeByteString, FieldTypeBinary:
+		value, _ := field.Interface.([]byte)
+		if len(value) > size {
+			if field.Type == FieldTypeByteString {
+				size = len(truncateString(string(value[:size+1]), size))
+			}
+
+			truncated := make([]byte, 0, size+len(TruncationMarker))
+			truncated = append(truncated, value[:size]...)
+			field.Interface = append(truncated, TruncationMarker...)
+		}
+	}
+
+	return field
+}
+
+// truncateString cut the value to at most size bytes, without splitting a rune.
+func truncateString(value string, size int) string {
+	for size > 0 && !utf8.RuneStart(value[size]) {
+		size--
+	}
+
+	return value[:size]
+}
@@ -0,0 +1,70 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"strings"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetFieldLimits(t *testing.T) {
+	t.Run("no limits, expect fields as is", func(t *testing.T) {
+		value := strings.Repeat("a", 100)
+		err := errors.New("failed", errors.String("key", value))
+
+		assert.Equal(t, errors.FieldLimits{}, errors.GetFieldLimits())
+		assert.Equal(t, []errors.Field{errors.String("key", value)}, errors.GetFields(err))
+	})
+
+	t.Run("too many fields, expect dropped fields with marker", func(t *testing.T) {
+		errors.SetFieldLimits(errors.FieldLimits{MaxFields: 2})
+		defer errors.SetFieldLimits(errors.FieldLimits{})
+
+		err := errors.New("failed", errors.Int("a", 1), errors.Int("b", 2), errors.Int("c", 3))
+		assert.Equal(t, []errors.Field{errors.Int("a", 1), errors.Int("b", 2), errors.Int(errors.KeyDroppedFields, 1)}, errors.GetFields(err))
+
+		err = errors.AddFields(err, errors.Int("d", 4))
+		assert.Equal(t, []errors.Field{errors.Int("a", 1), errors.Int("b", 2), errors.Int(errors.KeyDroppedFields, 2)}, errors.GetFields(err))
+	})
+
+	t.Run("large values, expect truncated values with marker", func(t *testing.T) {
+		errors.SetFieldLimits(errors.FieldLimits{MaxValueSize: 4})
+		defer errors.SetFieldLimits(errors.FieldLimits{})
+
+		err := errors.New("failed",
+			errors.String("str", "abcdef"),
+			errors.String("utf8", "abcé"),
+			errors.ByteString("bytes", []byte("abcdef")),
+			errors.Binary("bin", []byte{1, 2, 3, 4, 5}),
+			errors.String("short", "abc"),
+		)
+
+		fields := errors.GetFields(err)
+		assert.Equal(t, "abcd"+errors.TruncationMarker, fields[0].Value())
+		assert.Equal(t, "abc"+errors.TruncationMarker, fields[1].Value())
+		assert.Equal(t, []byte("abcd"+errors.TruncationMarker), fields[2].Value())
+		assert.Equal(t, append([]byte{1, 2, 3, 4}, errors.TruncationMarker...), fields[3].Value())
+		assert.Equal(t, "abc", fields[4].Value())
+	})
+}
+
+func TestWithFieldLimits(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error with many fields, expect limited copy", func(t *testing.T) {
+		err := errors.New("failed", errors.String("a", "value"), errors.Int("b", 2))
+		limited := errors.WithFieldLimits(err, errors.FieldLimits{MaxFields: 1, MaxValueSize: 2})
+
+		assert.Equal(t, []errors.Field{errors.String("a", "va"+errors.TruncationMarker), errors.Int(errors.KeyDroppedFields, 1)}, errors.GetFields(limited))
+		assert.Len(t, errors.GetFields(err), 2)
+		assert.Equal(t, "failed", limited.Error())
+	})
+
+	t.Run("not Error, expect error as is", func(t *testing.T) {
+		err := stdErrors.New("failed")
+
+		assert.Equal(t, err, errors.WithFieldLimits(err, errors.FieldLimits{MaxFields: 1}))
+	})
+}
@@ -0,0 +1,81 @@
+// Package logruserrors converts the fields of errors.Error to logrus.Fields, so logrus users can emit the structured fields directly.
+package logruserrors
+
+import (
+	"github.com/mrsoftware/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Fields convert the fields of the error chain to logrus.Fields.
+// repeated keys in the chain are handled by errors.GetDedupPolicy, context fields are skipped.
+func Fields(err error) logrus.Fields {
+	if err == nil {
+		return nil
+	}
+
+	fields := logrus.Fields{}
+
+	for _, field := range errors.DedupChainFields(err) {
+		if field.Type != errors.FieldTypeContext {
+			fields[field.Key] = value(field)
+		}
+	}
+
+	return fields
+}
+
+// WithError return entry with the error and its fields.
+func WithError(entry *logrus.Entry, err error) *logrus.Entry {
+	return entry.WithError(err).WithFields(Fields(err))
+}
+
+// Hook is a logrus.Hook that adds the fields of the error of entry (see logrus.WithError),
+// the fields of entry are not overridden.
+type Hook struct{}
+
+// Levels implement logrus.Hook.
+func (Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implement logrus.Hook.
+func (Hook) Fire(entry *logrus.Entry) error {
+	err, ok := entry.Data[logrus.ErrorKey].(error)
+	if !ok {
+		return nil
+	}
+
+	for key, value := range Fields(err) {
+		if _, ok := entry.Data[key]; !ok {
+			entry.Data[key] = value
+		}
+	}
+
+	return nil
+}
+
+func value(field errors.Field) interface{} {
+	switch field.Type { // nolint: exhaustive
+	case errors.FieldTypeNamespace:
+		fields, _ := field.NamespaceValue()
+		nested := make(logrus.Fields, len(fields))
+
+		for _, item := range fields {
+			if item.Type != errors.FieldTypeContext {
+				nested[item.Key] = value(item)
+			}
+		}
+
+		return nested
+	case errors.FieldTypeFloat64:
+		value, _ := field.Float64Value()
+
+		return value
+	case errors.FieldTypeByteString:
+		value, _ := field.StringValue()
+
+		return value
+	default:
+		return field.Value()
+	}
+}
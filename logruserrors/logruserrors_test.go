@@ -0,0 +1,83 @@
+package logruserrors_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/mrsoftware/errors/logruserrors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error has fields in chain, expect logrus fields", func(t *testing.T) {
+		cause := errors.New("cause", errors.String("id", "1"), errors.Int("attempt", 2))
+		err := errors.Wrap(cause, "some error",
+			errors.String("id", "2"),
+			errors.Float64("ratio", 0.5),
+			errors.NamedContext("ctx", context.Background()),
+		)
+
+		assert.Equal(t, logrus.Fields{"id": "2", "ratio": 0.5, "attempt": int64(2)}, logruserrors.Fields(err))
+	})
+
+	t.Run("error has namespace, expect nested fields", func(t *testing.T) {
+		err := errors.New("some error", errors.Namespace("db", errors.String("table", "users")))
+
+		assert.Equal(t, logrus.Fields{"db": logrus.Fields{"table": "users"}}, logruserrors.Fields(err))
+	})
+
+	t.Run("nil error, expect nil", func(t *testing.T) {
+		assert.Nil(t, logruserrors.Fields(nil))
+	})
+}
+
+func TestWithError(t *testing.T) {
+	t.Parallel()
+
+	record := log(t, func(logger *logrus.Logger) {
+		err := errors.New("some error", errors.CodeField(errors.CodeNotFound))
+
+		logruserrors.WithError(logrus.NewEntry(logger), err).Error("failed")
+	})
+
+	assert.Equal(t, "some error", record[logrus.ErrorKey])
+	assert.Equal(t, "not_found", record["code"])
+}
+
+func TestHook(t *testing.T) {
+	t.Parallel()
+
+	record := log(t, func(logger *logrus.Logger) {
+		logger.AddHook(logruserrors.Hook{})
+
+		err := errors.New("some error", errors.CodeField(errors.CodeNotFound), errors.String("id", "1"))
+
+		logger.WithError(err).WithField("id", "2").Error("failed")
+	})
+
+	assert.Equal(t, "not_found", record["code"])
+	assert.Equal(t, "2", record["id"])
+}
+
+func log(t *testing.T, fn func(logger *logrus.Logger)) map[string]interface{} {
+	t.Helper()
+
+	buffer := &bytes.Buffer{}
+	logger := logrus.New()
+	logger.SetOutput(buffer)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+
+	fn(logger)
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &record))
+
+	return record
+}
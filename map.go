@@ -0,0 +1,33 @@
+package errors
+
+// Fields is a list of fields.
+type Fields []Field
+
+// ToMap convert the fields to a map of key to Value(), the first field wins on duplicate keys,
+// so for chain fields (see GetChainFields) the outermost value is kept.
+// Namespace fields are converted to nested maps and context fields are skipped.
+func (f Fields) ToMap() map[string]interface{} {
+	values := make(map[string]interface{}, len(f))
+
+	for _, field := range f {
+		if _, ok := values[field.Key]; ok || field.Type == FieldTypeContext {
+			continue
+		}
+
+		if nested, ok := field.NamespaceValue(); ok {
+			values[field.Key] = Fields(nested).ToMap()
+
+			continue
+		}
+
+		values[field.Key] = field.Value()
+	}
+
+	return values
+}
+
+// FieldsMap convert the fields of the error chain to a map, see Fields.ToMap.
+// repeated keys in the chain are handled by the policy of GetDedupPolicy.
+func FieldsMap(err error) map[string]interface{} {
+	return Fields(DedupChainFields(err)).ToMap()
+}
@@ -0,0 +1,47 @@
+package errors_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFields_ToMap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fields with namespace and context, expect nested map without context", func(t *testing.T) {
+		fields := errors.Fields{
+			errors.String("name", "mrsoftware"),
+			errors.Int("count", 1),
+			errors.NamedContext("ctx", context.Background()),
+			errors.Namespace("db", errors.String("table", "users")),
+		}
+
+		assert.Equal(t, map[string]interface{}{
+			"name":  "mrsoftware",
+			"count": int64(1),
+			"db":    map[string]interface{}{"table": "users"},
+		}, fields.ToMap())
+	})
+
+	t.Run("no fields, expect empty map", func(t *testing.T) {
+		assert.Empty(t, errors.Fields(nil).ToMap())
+	})
+}
+
+func TestFieldsMap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("duplicate keys in chain, expect outermost value", func(t *testing.T) {
+		err := errors.New("inner", errors.String("id", "inner"), errors.String("table", "users"))
+		err = errors.Wrap(err, "outer", errors.String("id", "outer"))
+
+		assert.Equal(t, map[string]interface{}{"id": "outer", "table": "users"}, errors.FieldsMap(err))
+	})
+
+	t.Run("standard error, expect empty map", func(t *testing.T) {
+		assert.Empty(t, errors.FieldsMap(context.Canceled))
+	})
+}
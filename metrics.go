@@ -0,0 +1,84 @@
+package errors
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// RuntimeMetrics is the internal counters of the package, useful to observe the cost of errors in production.
+type RuntimeMetrics struct {
+	// ErrorsCreated is the number of created Error.
+	ErrorsCreated uint64
+
+	// StackCaptures is the number of captured stacktraces.
+	StackCaptures uint64
+
+	// StackPoolHits is the number of stacktraces that reused pooled storage.
+	StackPoolHits uint64
+
+	// StackPoolMisses is the number of stacktraces that allocated new storage.
+	StackPoolMisses uint64
+
+	// BytesFormatted is the number of bytes written by Error.Format, MultiError.Format and MultiError.MarshalJSON,
+	// the nested errors are counted once by the outermost one. EncodeError, HTML and the logger adapters are not counted.
+	BytesFormatted uint64
+}
+
+var metrics RuntimeMetrics
+
+// Metrics return a snapshot of internal counters.
+func Metrics() RuntimeMetrics {
+	snapshot := RuntimeMetrics{
+		ErrorsCreated:   atomic.LoadUint64(&metrics.ErrorsCreated),
+		StackCaptures:   atomic.LoadUint64(&metrics.StackCaptures),
+		StackPoolMisses: atomic.LoadUint64(&metrics.StackPoolMisses),
+		BytesFormatted:  atomic.LoadUint64(&metrics.BytesFormatted),
+	}
+
+	if snapshot.StackCaptures > snapshot.StackPoolMisses {
+		snapshot.StackPoolHits = snapshot.StackCaptures - snapshot.StackPoolMisses
+	}
+
+	return snapshot
+}
+
+// countingState is fmt.State that counts written bytes.
+type countingState struct {
+	fmt.State
+	written int
+}
+
+func (s *countingState) Write(b []byte) (int, error) {
+	n, err := s.State.Write(b)
+	s.written += n
+
+	return n, err
+}
+
+// record add written bytes to metrics.
+func (s *countingState) record() {
+	atomic.AddUint64(&metrics.BytesFormatted, uint64(s.written))
+}
+
+// uncountedFormatter formats a nested Error or MultiError without counting the bytes,
+// so the bytes are counted once by the outermost Format or MarshalJSON.
+type uncountedFormatter struct {
+	format func(state fmt.State, verb rune)
+}
+
+// Format call the format function.
+func (f uncountedFormatter) Format(state fmt.State, verb rune) {
+	f.format(state, verb)
+}
+
+// uncounted return v as uncountedFormatter if it's an Error or MultiError, otherwise v is returned as is.
+func uncounted(v interface{}) interface{} {
+	switch v := v.(type) {
+	case *Error:
+		return uncountedFormatter{format: v.format}
+	case *MultiError:
+		return uncountedFormatter{format: v.format}
+	default:
+		return v
+	}
+}
@@ -0,0 +1,60 @@
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	before := errors.Metrics()
+
+	err := errors.New("some error", errors.String("username", "mrsoftware"), errors.Stack("stack"))
+	formatted := fmt.Sprintf("%v", errors.New("some error"))
+
+	after := errors.Metrics()
+
+	assert.GreaterOrEqual(t, after.ErrorsCreated-before.ErrorsCreated, uint64(2))
+	assert.GreaterOrEqual(t, after.StackCaptures-before.StackCaptures, uint64(1))
+	assert.GreaterOrEqual(t, after.BytesFormatted-before.BytesFormatted, uint64(len(formatted)))
+	assert.Equal(t, after.StackCaptures, after.StackPoolHits+after.StackPoolMisses)
+	assert.NotNil(t, err)
+}
+
+func TestMetrics_BytesFormatted(t *testing.T) {
+	t.Run("nested errors are formatted, expect bytes counted once", func(t *testing.T) {
+		cause := errors.New("cause", errors.String("id", "1"))
+		err := errors.Wrap(cause, "wrapper", errors.NamedError("other", errors.New("other", errors.String("id", "2"))))
+
+		before := errors.Metrics()
+		formatted := fmt.Sprintf("%v", err)
+		after := errors.Metrics()
+
+		assert.Equal(t, uint64(len(formatted)), after.BytesFormatted-before.BytesFormatted)
+
+		before = errors.Metrics()
+		quoted := fmt.Sprintf("%q", err)
+		after = errors.Metrics()
+
+		assert.Equal(t, uint64(len(quoted)), after.BytesFormatted-before.BytesFormatted)
+	})
+
+	t.Run("multi error is formatted or marshaled, expect bytes counted once", func(t *testing.T) {
+		multi := errors.NewMultiError(errors.New("error 1", errors.String("id", "1")), errors.NewMultiError(errors.New("error 2")))
+
+		before := errors.Metrics()
+		formatted := fmt.Sprintf("%+v", multi)
+		after := errors.Metrics()
+
+		assert.Equal(t, uint64(len(formatted)), after.BytesFormatted-before.BytesFormatted)
+
+		before = errors.Metrics()
+		encoded, err := multi.MarshalJSON()
+		after = errors.Metrics()
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(len(encoded)), after.BytesFormatted-before.BytesFormatted)
+	})
+}
@@ -2,113 +2,456 @@ package errors
 
 import (
 	"bytes"
-	"errors"
+	stdErr "errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 const (
 	defaultErrorGroupSeparator = " | "
 )
 
-// MultiError is list of errors.
+// MultiError is list of errors, it's concurrent safe.
 type MultiError struct {
 	errors []error
 	mx     sync.Mutex
+
+	flatten     bool
+	separator   string
+	countPrefix bool
+	bullet      string
+	limit       int
+	dropped     int
+}
+
+// MultiErrorOption configure the MultiError, see NewMultiErrorWithOptions.
+type MultiErrorOption func(m *MultiError)
+
+// MultiErrorWithFlatten add the errors of the added MultiErrors instead of themselves,
+// so the list is always one level deep.
+func MultiErrorWithFlatten() MultiErrorOption {
+	return func(m *MultiError) {
+		m.flatten = true
+	}
+}
+
+// MultiErrorWithSeparator set the separator of the messages in Error, empty separator means the default " | ".
+func MultiErrorWithSeparator(separator string) MultiErrorOption {
+	return func(m *MultiError) {
+		m.separator = separator
+	}
+}
+
+// MultiErrorWithCountPrefix prefix the message of Error with the number of errors, like "3 errors occurred:".
+func MultiErrorWithCountPrefix() MultiErrorOption {
+	return func(m *MultiError) {
+		m.countPrefix = true
+	}
+}
+
+// MultiErrorWithBullets write each message of Error in a new line that starts with a tab and the bullet,
+// instead of separating them. with MultiErrorWithCountPrefix, it's the format of hashicorp/go-multierror.
+//
+//	errors.NewMultiErrorWithOptions(errors.MultiErrorWithCountPrefix(), errors.MultiErrorWithBullets("*"))
+func MultiErrorWithBullets(bullet string) MultiErrorOption {
+	return func(m *MultiError) {
+		m.bullet = bullet
+	}
+}
+
+// MultiErrorWithLimit keep at most n errors, the next errors are dropped and counted (see Dropped),
+// and reported by Error as "... and N more". n < 1 means no limit.
+func MultiErrorWithLimit(n int) MultiErrorOption {
+	return func(m *MultiError) {
+		m.limit = n
+	}
 }
 
 // NewMultiError create new MultiError error.
 func NewMultiError(errors ...error) *MultiError {
 	multi := &MultiError{}
 	for _, err := range errors {
-		multi.Add(err)
+		multi.add(err)
 	}
 
 	return multi
 }
 
+// NewMultiErrorWithOptions create new empty MultiError with the options.
+func NewMultiErrorWithOptions(options ...MultiErrorOption) *MultiError {
+	multi := &MultiError{}
+	for _, option := range options {
+		option(multi)
+	}
+
+	return multi
+}
+
+// clone return new empty MultiError with the options and the dropped count of m.
+func (m *MultiError) clone() *MultiError {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	return &MultiError{
+		flatten:     m.flatten,
+		separator:   m.separator,
+		countPrefix: m.countPrefix,
+		bullet:      m.bullet,
+		limit:       m.limit,
+		dropped:     m.dropped,
+	}
+}
+
 // Error sum of all errors.
 func (m *MultiError) Error() string {
-	if m.Len() == 0 {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	if len(m.errors) == 0 {
 		return ""
 	}
 
+	separator := m.separator
+	if separator == "" {
+		separator = defaultErrorGroupSeparator
+	}
+
 	buffer := &bytes.Buffer{}
+
+	if m.countPrefix {
+		buffer.WriteString(occurred(len(m.errors)))
+
+		if m.bullet == "" {
+			buffer.WriteByte(' ')
+		}
+	}
+
 	for index, err := range m.errors {
+		if m.bullet != "" {
+			buffer.WriteString("\n\t" + m.bullet + " ")
+		} else if index > 0 {
+			buffer.WriteString(separator)
+		}
+
 		buffer.WriteString(err.Error())
+	}
 
-		if index < len(m.errors)-1 {
-			buffer.WriteString(defaultErrorGroupSeparator)
+	if m.dropped > 0 {
+		if m.bullet != "" {
+			buffer.WriteString("\n\t" + m.bullet + " ")
+		} else {
+			buffer.WriteString(separator)
 		}
+
+		fmt.Fprintf(buffer, "... and %d more", m.dropped)
 	}
 
 	return buffer.String()
 }
 
-// Err of the multi error, return nil if no error is set.
+// Err of the multi error, return nil if no error is set, see ErrorOrNil.
 func (m *MultiError) Err() error {
-	if m.Len() == 0 {
+	return m.ErrorOrNil()
+}
+
+// ErrorOrNil return m as error if it has errors, otherwise nil (not a nil *MultiError in a non-nil error).
+// it's safe to call on a nil *MultiError, so the MultiError can be created lazily (see Append).
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || m.Len() == 0 {
 		return nil
 	}
 
 	return m
 }
 
-// Errors return all list of errors.
+// Errors return copy of the list of errors.
 func (m *MultiError) Errors() []error {
-	return m.errors
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	return append([]error(nil), m.errors...)
 }
 
 // Add new error to list.
 func (m *MultiError) Add(err error) {
+	if nested, ok := err.(*MultiError); ok && m.flatten { // nolint: errorlint
+		// the nested errors are taken before the lock, so adding m to itself does not deadlock.
+		errs := nested.flatErrors()
+
+		m.mx.Lock()
+		for _, err := range errs {
+			m.add(err)
+		}
+		m.mx.Unlock()
+
+		return
+	}
+
+	m.mx.Lock()
+	m.add(err)
+	m.mx.Unlock()
+}
+
+// add new error to list, the caller must hold the lock.
+func (m *MultiError) add(err error) {
 	if err == nil {
 		return
 	}
 
+	if m.limit > 0 && len(m.errors) >= m.limit {
+		m.dropped++
+
+		return
+	}
+
 	m.errors = append(m.errors, err)
 }
 
-// SafeAdd is like add but concurrent safe.
-func (m *MultiError) SafeAdd(err error) {
+// Dropped return the number of errors that are dropped by the limit, see MultiErrorWithLimit.
+func (m *MultiError) Dropped() int {
 	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	return m.dropped
+}
+
+// SafeAdd is Add, all methods are concurrent safe.
+func (m *MultiError) SafeAdd(err error) {
 	m.Add(err)
-	m.mx.Unlock()
 }
 
 // Len of errors.
 func (m *MultiError) Len() int {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
 	return len(m.errors)
 }
 
-// SafeLen is like len but concurrent safe.
+// SafeLen is Len, all methods are concurrent safe.
 func (m *MultiError) SafeLen() int {
-	m.mx.Lock()
-	defer m.mx.Unlock()
+	return m.Len()
+}
 
-	return len(m.errors)
+// Flatten return new MultiError that the errors of nested MultiErrors are added instead of themselves,
+// so the list is one level deep. the wrapped MultiErrors (e.g. by Wrap) are kept as is.
+func (m *MultiError) Flatten() *MultiError {
+	flat := m.clone()
+	for _, err := range m.flatErrors() {
+		flat.add(err)
+	}
+
+	return flat
 }
 
-// Unwrap return cause error (first error in list).
-func (m *MultiError) Unwrap() error {
-	if len(m.errors) == 0 {
-		return nil
+// flatErrors return the errors of m, the errors of nested MultiErrors are returned instead of them.
+func (m *MultiError) flatErrors() []error {
+	errs := m.Errors()
+
+	flat := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if nested, ok := err.(*MultiError); ok { // nolint: errorlint
+			flat = append(flat, nested.flatErrors()...)
+
+			continue
+		}
+
+		flat = append(flat, err)
 	}
 
-	m.mx.Lock()
-	defer m.mx.Unlock()
+	return flat
+}
+
+// As find the first error in list that matches target by errors.As, and set target to it.
+// errors.As also checks all errors by Unwrap, As makes it explicit for the code that calls it directly.
+func (m *MultiError) As(target interface{}) bool {
+	for _, err := range m.Errors() {
+		if stdErr.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Dedup return new MultiError that the duplicate errors (same code and message) are collapsed to the first one,
+// which is wrapped with the number of duplicates (KeyCount) as field if there is more than one.
+func (m *MultiError) Dedup() *MultiError {
+	type dedupKey struct {
+		code    Code
+		message string
+	}
+
+	errs := m.Errors()
+	keys := make([]dedupKey, 0, len(errs))
+	counts := make(map[dedupKey]int, len(errs))
+	first := make(map[dedupKey]error, len(errs))
+
+	for _, err := range errs {
+		key := dedupKey{code: GetCode(err), message: err.Error()}
+		if counts[key] == 0 {
+			keys = append(keys, key)
+			first[key] = err
+		}
+
+		counts[key]++
+	}
+
+	deduped := m.clone()
+
+	for _, key := range keys {
+		err := first[key]
+		if counts[key] > 1 {
+			// it's not a new error, so it's built without the constructors (no Stater, metrics, id or stack).
+			err = &Error{cause: err, fields: []Field{Int(KeyCount, counts[key])}}
+		}
+
+		deduped.errors = append(deduped.errors, err)
+	}
+
+	return deduped
+}
+
+// Filter return new MultiError with the errors that keep returns true for.
+func (m *MultiError) Filter(keep func(err error) bool) *MultiError {
+	kept, _ := m.Partition(keep)
+
+	return kept
+}
+
+// Map return new MultiError with the errors returned by f for each error, nil errors are dropped.
+func (m *MultiError) Map(f func(err error) error) *MultiError {
+	mapped := m.clone()
+
+	for _, err := range m.Errors() {
+		mapped.add(f(err))
+	}
+
+	return mapped
+}
+
+// Partition split the errors to two new MultiErrors, the errors that match returns true for and the rest.
+//
+//	retryable, fatal := multi.Partition(errors.IsRetryable)
+func (m *MultiError) Partition(match func(err error) bool) (*MultiError, *MultiError) {
+	matched, rest := m.clone(), m.clone()
+
+	for _, err := range m.Errors() {
+		if match(err) {
+			matched.add(err)
+
+			continue
+		}
+
+		rest.add(err)
+	}
 
-	return m.errors[0]
+	return matched, rest
 }
 
-// Is check errors for match.
-func (m *MultiError) Is(err error) bool {
+// Unwrap return copy of the errors, so errors.Is and errors.As match all errors in list.
+func (m *MultiError) Unwrap() []error {
 	m.mx.Lock()
 	defer m.mx.Unlock()
 
-	for i := range m.errors {
-		if errors.Is(err, m.errors[i]) {
-			return true
+	if len(m.errors) == 0 {
+		return nil
+	}
+
+	return append([]error(nil), m.errors...)
+}
+
+// Format implements fmt.Formatter, %s is Error, %v is a numbered list of errors with their fields and
+// %+v is a numbered list of errors with their verbose format (fields and stack traces).
+func (m *MultiError) Format(state fmt.State, verb rune) {
+	counter := &countingState{State: state}
+	defer counter.record()
+
+	m.format(counter, verb)
+}
+
+// format is Format without counting the written bytes.
+func (m *MultiError) format(state fmt.State, verb rune) {
+	errs := m.Errors()
+
+	switch {
+	case verb == 'q':
+		fmt.Fprint(state, strconv.Quote(m.Error()))
+	case verb != 'v' || len(errs) == 0:
+		fmt.Fprint(state, m.Error())
+	default:
+		format := "%v"
+		if state.Flag('+') {
+			format = "%+v"
+		}
+
+		fmt.Fprint(state, occurred(len(errs)))
+
+		for index, err := range errs {
+			// indent the lines of the error, so the verbose format of errors stays under its number.
+			formatted := strings.ReplaceAll(fmt.Sprintf(format, uncounted(err)), "\n", "\n\t")
+			fmt.Fprintf(state, "\n\t%d. %s", index+1, formatted)
+		}
+
+		if dropped := m.Dropped(); dropped > 0 {
+			fmt.Fprintf(state, "\n\t... and %d more", dropped)
 		}
 	}
+}
 
-	return false
+// MarshalJSON implements json.Marshaler, the errors are encoded as an array of objects by EncodeError,
+// and the nested MultiErrors as nested arrays.
+func (m *MultiError) MarshalJSON() ([]byte, error) {
+	encoded, err := m.marshalJSON()
+	if err == nil {
+		atomic.AddUint64(&metrics.BytesFormatted, uint64(len(encoded)))
+	}
+
+	return encoded, err
+}
+
+// marshalJSON is MarshalJSON without counting the written bytes.
+func (m *MultiError) marshalJSON() ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	buffer.WriteByte('[')
+
+	for index, err := range m.Errors() {
+		if index > 0 {
+			buffer.WriteByte(',')
+		}
+
+		if nested, ok := err.(*MultiError); ok { // nolint: errorlint
+			encoded, nestedErr := nested.marshalJSON()
+			if nestedErr != nil {
+				return nil, nestedErr
+			}
+
+			buffer.Write(encoded)
+
+			continue
+		}
+
+		enc := NewJSONEncoder()
+		if encodeErr := EncodeError(err, enc); encodeErr != nil {
+			return nil, encodeErr
+		}
+
+		buffer.Write(enc.Bytes())
+	}
+
+	buffer.WriteByte(']')
+
+	return buffer.Bytes(), nil
+}
+
+// occurred return the count prefix of n errors, like "3 errors occurred:".
+func occurred(n int) string {
+	if n == 1 {
+		return "1 error occurred:"
+	}
+
+	return strconv.Itoa(n) + " errors occurred:"
 }
@@ -1,7 +1,13 @@
 package errors
 
 import (
+	"context"
+	"encoding/json"
 	stdErr "errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -25,6 +31,247 @@ func TestMultiError_Error(t *testing.T) {
 	})
 }
 
+func TestMultiError_Format(t *testing.T) {
+	t.Run("no error in list, expect to return empty string", func(t *testing.T) {
+		assert.Equal(t, "", fmt.Sprintf("%v", NewMultiError()))
+	})
+
+	t.Run("format with s and q, expect the message", func(t *testing.T) {
+		err := NewMultiError(stdErr.New("error 1"), stdErr.New("error 2"))
+
+		assert.Equal(t, "error 1 | error 2", fmt.Sprintf("%s", err))
+		assert.Equal(t, `"error 1 | error 2"`, fmt.Sprintf("%q", err))
+	})
+
+	t.Run("format with v, expect numbered list with fields", func(t *testing.T) {
+		err := NewMultiError(New("error 1", String("id", "1")), stdErr.New("error 2"))
+
+		expected := "2 errors occurred:\n\t1. error 1: [{Key: id, Value: 1}]\n\t2. error 2"
+
+		assert.Equal(t, expected, fmt.Sprintf("%v", err))
+	})
+
+	t.Run("format with +v, expect numbered list with indented stack traces", func(t *testing.T) {
+		SetStackDepth(StacktraceFull)
+		defer SetStackDepth(StacktraceNone)
+
+		err := NewMultiError(New("error 1"), stdErr.New("error 2"))
+
+		formatted := fmt.Sprintf("%+v", err)
+
+		assert.True(t, strings.HasPrefix(formatted, "2 errors occurred:\n\t1. error 1\n\tgithub.com/mrsoftware/errors.TestMultiError_Format"), formatted)
+		assert.True(t, strings.HasSuffix(formatted, "\n\t2. error 2"), formatted)
+	})
+}
+
+func TestMultiError_MarshalJSON(t *testing.T) {
+	t.Run("no error in list, expect empty array", func(t *testing.T) {
+		encoded, err := json.Marshal(NewMultiError())
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[]`, string(encoded))
+	})
+
+	t.Run("errors and nested multi error in list, expect array of errors", func(t *testing.T) {
+		multi := NewMultiError(
+			New("error 1", String("id", "1")),
+			stdErr.New("error 2"),
+			NewMultiError(stdErr.New("error 3")),
+		)
+
+		encoded, err := json.Marshal(multi)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{"message":"error 1","id":"1"},{"message":"error 2"},[{"message":"error 3"}]]`, string(encoded))
+	})
+}
+
+func TestMultiError_Flatten(t *testing.T) {
+	t.Run("multi errors are nested, expect one level list", func(t *testing.T) {
+		error1 := stdErr.New("error 1")
+		error2 := stdErr.New("error 2")
+		error3 := Wrap(NewMultiError(stdErr.New("error 3")), "wrapper")
+
+		err := NewMultiError(error1, NewMultiError(error2, NewMultiError(error3)))
+
+		assert.Equal(t, []error{error1, error2, error3}, err.Flatten().Errors())
+		assert.Len(t, err.Errors(), 2)
+	})
+
+	t.Run("flatten option is set, expect nested errors added on Add", func(t *testing.T) {
+		error1 := stdErr.New("error 1")
+		error2 := stdErr.New("error 2")
+
+		err := NewMultiErrorWithOptions(MultiErrorWithFlatten())
+		err.Add(error1)
+		err.Add(NewMultiError(error2, NewMultiError(error1)))
+
+		assert.Equal(t, []error{error1, error2, error1}, err.Errors())
+		assert.Equal(t, "error 1 | error 2 | error 1", err.Error())
+	})
+
+	t.Run("flatten option is set and added to itself, expect its errors added again", func(t *testing.T) {
+		error1 := stdErr.New("error 1")
+
+		err := NewMultiErrorWithOptions(MultiErrorWithFlatten())
+		err.Add(error1)
+		err.Add(err)
+
+		assert.Equal(t, []error{error1, error1}, err.Errors())
+	})
+}
+
+func TestMultiError_Dedup(t *testing.T) {
+	t.Run("identical errors in list, expect collapsed with count", func(t *testing.T) {
+		refused := stdErr.New("connection refused")
+		timeout := stdErr.New("timeout")
+
+		err := NewMultiError(refused, timeout, stdErr.New("connection refused"), refused).Dedup()
+
+		assert.Equal(t, 2, err.Len())
+		assert.Equal(t, "connection refused | timeout", err.Error())
+		assert.Equal(t, int64(3), GetField(err.Errors()[0], KeyCount).Value())
+		assert.True(t, stdErr.Is(err.Errors()[0], refused))
+		assert.Equal(t, timeout, err.Errors()[1])
+	})
+
+	t.Run("same message with different codes, expect both kept", func(t *testing.T) {
+		err := NewMultiError(
+			New("failed", CodeField(CodeNotFound)),
+			New("failed", CodeField(CodeInternal)),
+		).Dedup()
+
+		assert.Equal(t, 2, err.Len())
+	})
+
+	t.Run("deduped many times, expect no new error", func(t *testing.T) {
+		stater := NewCountStater()
+		SetDefaultStat(stater)
+		defer SetDefaultStat(nil)
+
+		multi := NewMultiError(stdErr.New("failure"), stdErr.New("failure"))
+		created := Metrics().ErrorsCreated
+
+		multi.Dedup()
+		multi.Dedup()
+
+		assert.Empty(t, stater.Snapshot())
+		assert.Equal(t, created, Metrics().ErrorsCreated)
+	})
+}
+
+func TestMultiError_Filter(t *testing.T) {
+	t.Run("canceled errors in list, expect dropped", func(t *testing.T) {
+		failure := stdErr.New("failure")
+
+		err := NewMultiError(context.Canceled, failure, Wrap(context.Canceled, "task")).Filter(func(err error) bool {
+			return !stdErr.Is(err, context.Canceled)
+		})
+
+		assert.Equal(t, []error{failure}, err.Errors())
+	})
+}
+
+func TestMultiError_Map(t *testing.T) {
+	t.Run("map errors, expect mapped errors and nil errors dropped", func(t *testing.T) {
+		failure := stdErr.New("failure")
+
+		err := NewMultiError(failure, context.Canceled).Map(func(err error) error {
+			if stdErr.Is(err, context.Canceled) {
+				return nil
+			}
+
+			return Wrap(err, "task")
+		})
+
+		assert.Equal(t, "task: failure", err.Error())
+		assert.True(t, stdErr.Is(err, failure))
+	})
+}
+
+func TestMultiError_Partition(t *testing.T) {
+	t.Run("retryable and fatal errors, expect split", func(t *testing.T) {
+		retryable := MarkRetryable(stdErr.New("retryable"))
+		fatal := stdErr.New("fatal")
+
+		matched, rest := NewMultiError(retryable, fatal).Partition(IsRetryable)
+
+		assert.Equal(t, []error{retryable}, matched.Errors())
+		assert.Equal(t, []error{fatal}, rest.Errors())
+	})
+}
+
+func TestNewMultiErrorWithOptions(t *testing.T) {
+	error1 := stdErr.New("error 1")
+	error2 := stdErr.New("error 2")
+
+	t.Run("separator is set, expect messages separated by it", func(t *testing.T) {
+		err := NewMultiErrorWithOptions(MultiErrorWithSeparator("; "))
+		err.Add(error1)
+		err.Add(error2)
+
+		assert.Equal(t, "error 1; error 2", err.Error())
+	})
+
+	t.Run("count prefix is set, expect the number of errors before messages", func(t *testing.T) {
+		err := NewMultiErrorWithOptions(MultiErrorWithCountPrefix())
+		err.Add(error1)
+
+		assert.Equal(t, "1 error occurred: error 1", err.Error())
+
+		err.Add(error2)
+
+		assert.Equal(t, "2 errors occurred: error 1 | error 2", err.Error())
+	})
+
+	t.Run("count prefix and bullets are set, expect go-multierror format", func(t *testing.T) {
+		err := NewMultiErrorWithOptions(MultiErrorWithCountPrefix(), MultiErrorWithBullets("*"))
+		err.Add(error1)
+		err.Add(error2)
+
+		assert.Equal(t, "2 errors occurred:\n\t* error 1\n\t* error 2", err.Error())
+	})
+
+	t.Run("options are set, expect kept by derived multi errors", func(t *testing.T) {
+		err := NewMultiErrorWithOptions(MultiErrorWithSeparator(", "))
+		err.Add(error1)
+		err.Add(error2)
+
+		assert.Equal(t, "error 1, error 2", err.Filter(func(error) bool { return true }).Error())
+	})
+}
+
+func TestMultiErrorWithLimit(t *testing.T) {
+	t.Run("more errors than limit, expect extra errors dropped and counted", func(t *testing.T) {
+		err := NewMultiErrorWithOptions(MultiErrorWithLimit(2))
+		for i := 0; i < 5; i++ {
+			err.Add(fmt.Errorf("error %d", i))
+		}
+
+		assert.Equal(t, 2, err.Len())
+		assert.Equal(t, 3, err.Dropped())
+		assert.Equal(t, "error 0 | error 1 | ... and 3 more", err.Error())
+		assert.Equal(t, "2 errors occurred:\n\t1. error 0\n\t2. error 1\n\t... and 3 more", fmt.Sprintf("%v", err))
+	})
+
+	t.Run("bullets are set, expect dropped errors in a new line", func(t *testing.T) {
+		err := NewMultiErrorWithOptions(MultiErrorWithLimit(1), MultiErrorWithBullets("*"))
+		err.Add(stdErr.New("error 1"))
+		err.Add(stdErr.New("error 2"))
+
+		assert.Equal(t, "\n\t* error 1\n\t* ... and 1 more", err.Error())
+	})
+
+	t.Run("errors are filtered or mapped, expect dropped errors still counted", func(t *testing.T) {
+		err := NewMultiErrorWithOptions(MultiErrorWithLimit(1))
+		err.Add(stdErr.New("error 1"))
+		err.Add(stdErr.New("error 2"))
+
+		assert.Equal(t, "error 1 | ... and 1 more", err.Filter(func(error) bool { return true }).Error())
+		assert.Equal(t, "error 1 | ... and 1 more", err.Map(func(err error) error { return err }).Error())
+	})
+}
+
 func TestMultiError_Err(t *testing.T) {
 	t.Run("have no error, expect to get nil", func(t *testing.T) {
 		err := NewMultiError(nil, nil)
@@ -39,6 +286,25 @@ func TestMultiError_Err(t *testing.T) {
 	})
 }
 
+func TestMultiError_ErrorOrNil(t *testing.T) {
+	t.Run("nil multi error, expect to get nil error", func(t *testing.T) {
+		var err *MultiError
+
+		assert.NoError(t, err.ErrorOrNil())
+		assert.NoError(t, err.Err())
+	})
+
+	t.Run("no error in list, expect to get nil error", func(t *testing.T) {
+		assert.NoError(t, NewMultiError().ErrorOrNil())
+	})
+
+	t.Run("have errors, expect to get it", func(t *testing.T) {
+		err := NewMultiError(stdErr.New("some error"))
+
+		assert.Equal(t, err, err.ErrorOrNil())
+	})
+}
+
 func TestMultiError_Errors(t *testing.T) {
 	errList := []error{stdErr.New("some error")}
 
@@ -70,6 +336,28 @@ func TestMultiError_SafeAdd(t *testing.T) {
 	})
 }
 
+func TestMultiError_Add(t *testing.T) {
+	t.Run("add errors from multiple goroutines, expect all of them in list", func(t *testing.T) {
+		err := NewMultiError()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				err.Add(stdErr.New("some error"))
+				_ = err.Len()
+				_ = err.Error()
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 100, err.Len())
+		assert.Len(t, err.Errors(), 100)
+	})
+}
+
 func TestMultiError_LenSafe(t *testing.T) {
 	errList := []error{stdErr.New("some error")}
 
@@ -85,13 +373,13 @@ func TestMultiError_Unwrap(t *testing.T) {
 		assert.Nil(t, err.Unwrap())
 	})
 
-	t.Run("errors is set, expect to return first error as root error", func(t *testing.T) {
+	t.Run("errors is set, expect to return all errors", func(t *testing.T) {
 		error1 := stdErr.New("error 1")
 		error2 := stdErr.New("error 2")
 
 		err := NewMultiError(error1, error2)
 
-		assert.Equal(t, error1, err.Unwrap())
+		assert.Equal(t, []error{error1, error2}, err.Unwrap())
 	})
 }
 
@@ -114,4 +402,39 @@ func TestMultiError_Is(t *testing.T) {
 
 		assert.False(t, stdErr.Is(err, error3))
 	})
+
+	t.Run("requested err is wrapped by an error in list, expect to get true", func(t *testing.T) {
+		error1 := stdErr.New("error 1")
+
+		err := NewMultiError(stdErr.New("error 2"), Wrap(error1, "wrapper"))
+
+		assert.True(t, stdErr.Is(err, error1))
+	})
+}
+
+func TestMultiError_As(t *testing.T) {
+	t.Run("an error in list has the type, expect to get it", func(t *testing.T) {
+		custom := New("custom")
+
+		err := NewMultiError(stdErr.New("error 1"), custom)
+
+		var target *Error
+		assert.True(t, stdErr.As(err, &target))
+		assert.Equal(t, custom, target)
+	})
+
+	t.Run("an error in chain of second error has the type, expect As method to find it", func(t *testing.T) {
+		var signal ErrSignal
+
+		err := NewMultiError(stdErr.New("error 1"), Wrap(ErrSignal{Signal: os.Interrupt}, "wrapper"))
+
+		assert.True(t, err.As(&signal))
+		assert.Equal(t, os.Interrupt, signal.Signal)
+	})
+
+	t.Run("no error has the type, expect false", func(t *testing.T) {
+		var signal ErrSignal
+
+		assert.False(t, NewMultiError(stdErr.New("error 1")).As(&signal))
+	})
 }
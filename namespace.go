@@ -0,0 +1,20 @@
+package errors
+
+// Namespace constructs a field that groups the fields under the key, so large errors can organize
+// request, user or db context separately. Value() of the field is the grouped []Field.
+//
+//	errors.New("query failed", errors.Namespace("db", errors.String("table", "users"), errors.Int("rows", 0)))
+func Namespace(key string, fields ...Field) Field {
+	return Field{Key: key, Type: FieldTypeNamespace, Interface: copyFields(fields, 0)}
+}
+
+// NamespaceValue return the grouped fields of Namespace fields.
+func (f Field) NamespaceValue() ([]Field, bool) {
+	if f.Type != FieldTypeNamespace {
+		return nil, false
+	}
+
+	fields, ok := f.Interface.([]Field)
+
+	return fields, ok
+}
@@ -0,0 +1,60 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespace(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("query failed",
+		errors.Namespace("db", errors.String("table", "users"), errors.Namespace("stats", errors.Int("rows", 0))),
+		errors.String("id", "1"),
+	)
+
+	t.Run("format, expect hierarchy", func(t *testing.T) {
+		assert.Equal(t,
+			"query failed: [{Key: db, Value: [{Key: table, Value: users} {Key: stats, Value: [{Key: rows, Value: 0}]}]} {Key: id, Value: 1}]",
+			fmt.Sprintf("%v", err),
+		)
+		assert.Equal(t, "[db: [[table: users]]]", fmt.Sprintf("%s", errors.Namespace("db", errors.String("table", "users"))))
+	})
+
+	t.Run("json, expect nested objects", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		require.NoError(t, errors.WriteHTTPError(recorder, err))
+
+		var body errors.HTTPErrorBody
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+
+		assert.Equal(t, map[string]interface{}{
+			"db": map[string]interface{}{"table": "users", "stats": map[string]interface{}{"rows": float64(0)}},
+			"id": "1",
+		}, body.Fields)
+	})
+
+	t.Run("slog, expect groups", func(t *testing.T) {
+		assert.Equal(t,
+			slog.Group("db", slog.String("table", "users"), slog.Group("stats", slog.Int64("rows", 0))),
+			errors.GetField(err, "db").SlogAttr(),
+		)
+	})
+
+	t.Run("namespace value, expect grouped fields", func(t *testing.T) {
+		fields, ok := errors.GetField(err, "db").NamespaceValue()
+
+		assert.True(t, ok)
+		assert.Len(t, fields, 2)
+
+		_, ok = errors.GetField(err, "id").NamespaceValue()
+		assert.False(t, ok)
+	})
+}
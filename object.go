@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"time"
+)
+
+// FieldEncoder is used by FieldMarshaler to describe the structured representation of a value.
+type FieldEncoder interface {
+	AddString(key, value string)
+	AddInt64(key string, value int64)
+	AddUint64(key string, value uint64)
+	AddFloat64(key string, value float64)
+	AddBool(key string, value bool)
+	AddTime(key string, value time.Time)
+	AddDuration(key string, value time.Duration)
+	AddObject(key string, value FieldMarshaler) error
+	AddReflected(key string, value interface{}) error
+}
+
+// FieldMarshaler allows domain types to describe their own structured representation, instead of Reflect.
+type FieldMarshaler interface {
+	MarshalFields(enc FieldEncoder) error
+}
+
+// FieldMarshalerFunc is a type adapter that turns a function into a FieldMarshaler.
+type FieldMarshalerFunc func(enc FieldEncoder) error
+
+// MarshalFields calls f(enc).
+func (f FieldMarshalerFunc) MarshalFields(enc FieldEncoder) error {
+	return f(enc)
+}
+
+// Object constructs a field with the given key and FieldMarshaler.
+// Value() of the field is a map[string]interface{} built by the marshaler.
+func Object(key string, val FieldMarshaler) Field {
+	return Field{Key: key, Type: FieldTypeObject, Interface: val}
+}
+
+// ObjectValue return the marshaler of Object fields.
+func (f Field) ObjectValue() (FieldMarshaler, bool) {
+	if f.Type != FieldTypeObject {
+		return nil, false
+	}
+
+	marshaler, ok := f.Interface.(FieldMarshaler)
+
+	return marshaler, ok
+}
+
+// marshalObject return the map representation of the marshaler, or the error of marshaling.
+func marshalObject(value interface{}) interface{} {
+	marshaler, ok := value.(FieldMarshaler)
+	if !ok || marshaler == nil {
+		return nil
+	}
+
+	enc := mapEncoder{}
+	if err := marshaler.MarshalFields(enc); err != nil {
+		return err
+	}
+
+	return map[string]interface{}(enc)
+}
+
+// mapEncoder is a FieldEncoder that collects the values in a map.
+type mapEncoder map[string]interface{}
+
+func (m mapEncoder) AddString(key, value string)                 { m[key] = value }
+func (m mapEncoder) AddInt64(key string, value int64)            { m[key] = value }
+func (m mapEncoder) AddUint64(key string, value uint64)          { m[key] = value }
+func (m mapEncoder) AddFloat64(key string, value float64)        { m[key] = value }
+func (m mapEncoder) AddBool(key string, value bool)              { m[key] = value }
+func (m mapEncoder) AddTime(key string, value time.Time)         { m[key] = value }
+func (m mapEncoder) AddDuration(key string, value time.Duration) { m[key] = value }
+
+func (m mapEncoder) AddObject(key string, value FieldMarshaler) error {
+	nested := mapEncoder{}
+	if err := value.MarshalFields(nested); err != nil {
+		return err
+	}
+
+	m[key] = map[string]interface{}(nested)
+
+	return nil
+}
+
+func (m mapEncoder) AddReflected(key string, value interface{}) error {
+	m[key] = value
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testUser struct {
+	ID      int64
+	Name    string
+	Admin   bool
+	Created time.Time
+}
+
+func (u testUser) MarshalFields(enc errors.FieldEncoder) error {
+	enc.AddInt64("id", u.ID)
+	enc.AddString("name", u.Name)
+	enc.AddBool("admin", u.Admin)
+
+	return enc.AddObject("meta", errors.FieldMarshalerFunc(func(enc errors.FieldEncoder) error {
+		enc.AddTime("created", u.Created)
+
+		return nil
+	}))
+}
+
+func TestObject(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	user := testUser{ID: 1, Name: "mrsoftware", Created: created}
+
+	t.Run("object field, expect value of the marshaler", func(t *testing.T) {
+		field := errors.Object("user", user)
+
+		assert.Equal(t, "Object", field.Type.String())
+		assert.Equal(t, map[string]interface{}{
+			"id":    int64(1),
+			"name":  "mrsoftware",
+			"admin": false,
+			"meta":  map[string]interface{}{"created": created},
+		}, field.Value())
+
+		marshaler, ok := field.ObjectValue()
+		assert.True(t, ok)
+		assert.Equal(t, user, marshaler)
+	})
+
+	t.Run("marshaler failed, expect the error as value", func(t *testing.T) {
+		failure := fmt.Errorf("failure")
+		field := errors.Object("user", errors.FieldMarshalerFunc(func(enc errors.FieldEncoder) error {
+			return failure
+		}))
+
+		assert.Equal(t, failure, field.Value())
+	})
+
+	t.Run("not object field, expect false", func(t *testing.T) {
+		_, ok := errors.String("user", "mrsoftware").ObjectValue()
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,84 @@
+package errors
+
+import (
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+var autoOperation int32
+
+// SetAutoOperation enable or disable attaching the Op field of the caller on Wrap and Wrapf.
+// it's disabled by default, since it has the cost of capturing a single frame.
+func SetAutoOperation(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+
+	atomic.StoreInt32(&autoOperation, value)
+}
+
+// AutoOperationEnabled report whether Wrap attaches the Op field automatically.
+func AutoOperationEnabled() bool {
+	return atomic.LoadInt32(&autoOperation) == 1
+}
+
+// Op constructs an operation field with the name of the calling function, like "api.GetUser".
+func Op() Field {
+	return Operation(callerFunction(1)) // skip Op
+}
+
+// OpTrace return the operations of the error chain followed by the root cause message,
+// like "api.GetUser: store.Query: not found".
+func OpTrace(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	ops := make([]string, 0)
+
+	for _, field := range GetChainFields(err) {
+		if field.Key == KeyOperation && field.Type == FieldTypeString {
+			ops = append(ops, field.Str)
+		}
+	}
+
+	if len(ops) == 0 {
+		return err.Error()
+	}
+
+	return strings.Join(ops, ": ") + ": " + Cause(err).Error()
+}
+
+// callerFunction return the short function name of the caller, skip=0 identifies the caller of callerFunction.
+func callerFunction(skip int) string {
+	pcs := make([]uintptr, 1)
+	if callers(skip+1, pcs) == 0 {
+		return ""
+	}
+
+	frame, _ := runtime.CallersFrames(pcs).Next()
+
+	return shortFunctionName(frame.Function)
+}
+
+// shortFunctionName trim the package path of function name, "github.com/org/api.GetUser" becomes "api.GetUser".
+func shortFunctionName(name string) string {
+	if index := strings.LastIndex(name, "/"); index >= 0 {
+		return name[index+1:]
+	}
+
+	return name
+}
+
+// hasField check if any of fields has the key.
+func hasField(fields []Field, key string) bool {
+	for _, field := range fields {
+		if field.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,47 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOp(t *testing.T) {
+	t.Parallel()
+
+	field := errors.Op()
+
+	assert.Equal(t, errors.KeyOperation, field.Key)
+	assert.Equal(t, "errors_test.TestOp", field.Value())
+}
+
+func TestOpTrace(t *testing.T) {
+	t.Parallel()
+
+	t.Run("chain has operations, expect operation trace", func(t *testing.T) {
+		cause := stdErrors.New("not found")
+		err := errors.Wrap(errors.Wrap(cause, "query", errors.Operation("store.Query")), "get user", errors.Operation("api.GetUser"))
+
+		assert.Equal(t, "api.GetUser: store.Query: not found", errors.OpTrace(err))
+	})
+
+	t.Run("chain has no operation, expect error message", func(t *testing.T) {
+		err := errors.Wrap(stdErrors.New("not found"), "get user")
+
+		assert.Equal(t, "get user: not found", errors.OpTrace(err))
+	})
+}
+
+func TestSetAutoOperation(t *testing.T) {
+	errors.SetAutoOperation(true)
+	defer errors.SetAutoOperation(false)
+
+	cause := stdErrors.New("cause")
+
+	assert.Equal(t, "errors_test.TestSetAutoOperation", errors.GetField(errors.Wrap(cause, "msg"), errors.KeyOperation).Value())
+	assert.Equal(t, "errors_test.TestSetAutoOperation", errors.GetField(errors.Wrapf(cause, "msg %d", 1), errors.KeyOperation).Value())
+	assert.Equal(t, "custom", errors.GetField(errors.Wrap(cause, "msg", errors.Operation("custom")), errors.KeyOperation).Value())
+	assert.True(t, errors.IsNilField(errors.GetField(errors.New("msg"), errors.KeyOperation)))
+}
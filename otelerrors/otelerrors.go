@@ -0,0 +1,42 @@
+// Package otelerrors integrates errors with OpenTelemetry, spans for the tasks of errors.WaitGroup and an errors.Stater for metrics.
+package otelerrors
+
+import (
+	"context"
+
+	"github.com/mrsoftware/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultSpanName is the span name of the tasks without name.
+const DefaultSpanName = "task"
+
+// WaitGroupWithSpans start a child span of the task context for each task of the group, the error of the task
+// is recorded on the span and its status is set. the span is named by the task name, or DefaultSpanName.
+//
+//	wg := errors.NewWaitGroup(otelerrors.WaitGroupWithSpans(otel.Tracer("worker")))
+func WaitGroupWithSpans(tracer trace.Tracer) errors.WaitGroupOption {
+	return errors.WaitGroupWithTaskWrapper(func(ctx context.Context, name string, index int, next func(ctx context.Context) error) error {
+		spanName := name
+		if spanName == "" {
+			spanName = DefaultSpanName
+		}
+
+		ctx, span := tracer.Start(ctx, spanName, trace.WithAttributes(attribute.Int(errors.KeyTaskIndex, index)))
+		defer span.End()
+
+		err := next(ctx)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			return err
+		}
+
+		span.SetStatus(codes.Ok, "")
+
+		return nil
+	})
+}
@@ -0,0 +1,49 @@
+package otelerrors_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/mrsoftware/errors/otelerrors"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWaitGroupWithSpans(t *testing.T) {
+	t.Parallel()
+
+	t.Run("tasks are done, expect a child span for each task with its status", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+		tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+		ctx, parent := tracer.Start(context.Background(), "parent")
+		wg := errors.NewWaitGroup(otelerrors.WaitGroupWithSpans(tracer))
+
+		wg.DoNamed(ctx, "fail", func(ctx context.Context) error {
+			assert.True(t, trace.SpanContextFromContext(ctx).IsValid())
+
+			return stdErrors.New("failure")
+		})
+		wg.DoCtx(ctx, func(ctx context.Context) error { return nil })
+
+		assert.Error(t, wg.Wait())
+		parent.End()
+
+		statuses := make(map[string]codes.Code)
+		for _, span := range recorder.Ended() {
+			if span.Name() == "parent" {
+				continue
+			}
+
+			assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+			statuses[span.Name()] = span.Status().Code
+		}
+
+		assert.Equal(t, map[string]codes.Code{"fail": codes.Error, otelerrors.DefaultSpanName: codes.Ok}, statuses)
+	})
+}
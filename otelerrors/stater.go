@@ -0,0 +1,58 @@
+package otelerrors
+
+import (
+	"context"
+
+	"github.com/mrsoftware/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Attribute keys of the counter, the labels of errors.StatLabels are added after them.
+const (
+	AttributeCode     = "code"
+	AttributeSeverity = "severity"
+	AttributeTemplate = "template"
+)
+
+// Stater is an errors.Stater that adds to a counter instrument with the code, severity and template name of errors
+// as attributes, it's the OpenTelemetry counterpart of prometheuserrors.Stater.
+//
+//	stater, err := otelerrors.NewStater(otel.Meter("service"), "errors", nil)
+//	errors.SetDefaultStat(stater)
+type Stater struct {
+	counter metric.Int64Counter
+	labels  *errors.StatLabels
+}
+
+// NewStater create new Stater with a counter of the name, labels can be nil or add extra attributes from the fields of errors.
+func NewStater(meter metric.Meter, name string, labels *errors.StatLabels) (*Stater, error) {
+	counter, err := meter.Int64Counter(name, metric.WithDescription("Number of errors."), metric.WithUnit("{error}"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stater{counter: counter, labels: labels}, nil
+}
+
+// OnError add one to the counter with the attributes of err, nil errors are ignored.
+func (s *Stater) OnError(err error) {
+	if err == nil {
+		return
+	}
+
+	attributes := []attribute.KeyValue{
+		attribute.String(AttributeCode, string(errors.GetCode(err))),
+		attribute.String(AttributeSeverity, errors.GetSeverity(err).String()),
+		attribute.String(AttributeTemplate, errors.TemplateName(err)),
+	}
+
+	if s.labels != nil {
+		values := s.labels.Values(err)
+		for i, key := range s.labels.Keys() {
+			attributes = append(attributes, attribute.String(key, values[i]))
+		}
+	}
+
+	s.counter.Add(context.Background(), 1, metric.WithAttributes(attributes...))
+}
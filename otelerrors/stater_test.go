@@ -0,0 +1,48 @@
+package otelerrors_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/mrsoftware/errors/otelerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestStater(t *testing.T) {
+	t.Parallel()
+
+	t.Run("errors are reported, expect counter added by attributes", func(t *testing.T) {
+		reader := sdkmetric.NewManualReader()
+		meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test")
+
+		stater, err := otelerrors.NewStater(meter, "errors", errors.NewStatLabels(10, "region"))
+		require.NoError(t, err)
+
+		stater.OnError(errors.New("failure", errors.CodeField(errors.CodeNotFound), errors.String("region", "eu")))
+		stater.OnError(errors.New("failure", errors.CodeField(errors.CodeNotFound), errors.String("region", "eu")))
+		stater.OnError(nil)
+
+		var data metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &data))
+		require.Len(t, data.ScopeMetrics, 1)
+		require.Len(t, data.ScopeMetrics[0].Metrics, 1)
+
+		sum, ok := data.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+		require.True(t, ok)
+		require.Len(t, sum.DataPoints, 1)
+
+		point := sum.DataPoints[0]
+		assert.Equal(t, int64(2), point.Value)
+		assert.Equal(t, attribute.NewSet(
+			attribute.String(otelerrors.AttributeCode, string(errors.CodeNotFound)),
+			attribute.String(otelerrors.AttributeSeverity, errors.SeverityError.String()),
+			attribute.String(otelerrors.AttributeTemplate, ""),
+			attribute.String("region", "eu"),
+		), point.Attributes)
+	})
+}
@@ -0,0 +1,97 @@
+package errors
+
+import "sync"
+
+// Pipe is a closable error conduit for producer/consumer patterns that outlive a single WaitGroup.
+// producers Send errors and Close the Pipe when they are done, consumers wait for the First or All errors.
+type Pipe struct {
+	mx       sync.Mutex
+	errors   *MultiError
+	first    chan struct{}
+	done     chan struct{}
+	hasFirst bool
+	closed   bool
+}
+
+// NewPipe create new Pipe.
+func NewPipe() *Pipe {
+	return &Pipe{errors: NewMultiError(), first: make(chan struct{}), done: make(chan struct{})}
+}
+
+// Send the error to the Pipe, nil errors are ignored.
+// it returns false if the Pipe is closed and the error is not accepted.
+func (p *Pipe) Send(err error) bool {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if p.closed {
+		return false
+	}
+
+	if err == nil {
+		return true
+	}
+
+	p.errors.Add(err)
+
+	if !p.hasFirst {
+		p.hasFirst = true
+		close(p.first)
+	}
+
+	return true
+}
+
+// Close the Pipe, no more errors are accepted after it. closing a closed Pipe does nothing.
+func (p *Pipe) Close() {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if p.closed {
+		return
+	}
+
+	p.closed = true
+	close(p.done)
+}
+
+// Done return a channel that is closed when the Pipe is closed.
+func (p *Pipe) Done() <-chan struct{} {
+	return p.done
+}
+
+// First block until the first error is sent and return it, nil is returned if the Pipe is closed with no error.
+func (p *Pipe) First() error {
+	select {
+	case <-p.first:
+	case <-p.done:
+	}
+
+	errs := p.errors.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs[0]
+}
+
+// All block until the Pipe is closed and return all sent errors.
+func (p *Pipe) All() *MultiError {
+	<-p.done
+
+	return p.errors
+}
+
+// DrainPipe make the WaitGroup to wait for the Pipe to be closed and record all of its errors like Done,
+// so the options of the WaitGroup (e.g. WaitGroupIgnore) are applied to them.
+func (g *WaitGroup) DrainPipe(p *Pipe) {
+	g.Add(1)
+
+	go func() {
+		for _, err := range p.All().Errors() {
+			g.record(err, nil)
+		}
+
+		g.Done(nil)
+	}()
+}
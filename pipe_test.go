@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipe(t *testing.T) {
+	t.Run("errors are sent, expect first and all errors", func(t *testing.T) {
+		error1 := errors.New("error 1")
+		error2 := errors.New("error 2")
+		pipe := NewPipe()
+
+		go func() {
+			pipe.Send(error1)
+			pipe.Send(nil)
+			pipe.Send(error2)
+			pipe.Close()
+		}()
+
+		assert.Equal(t, error1, pipe.First())
+		assert.Equal(t, []error{error1, error2}, pipe.All().Errors())
+		assert.False(t, pipe.Send(errors.New("error 3")))
+	})
+
+	t.Run("closed without error, expect nil", func(t *testing.T) {
+		pipe := NewPipe()
+		pipe.Close()
+		pipe.Close()
+
+		assert.Nil(t, pipe.First())
+		assert.Nil(t, pipe.All().Err())
+	})
+
+	t.Run("first error is sent before close, expect First to not wait for close", func(t *testing.T) {
+		error1 := errors.New("error 1")
+		pipe := NewPipe()
+		pipe.Send(error1)
+
+		select {
+		case <-pipe.Done():
+			t.Fatal("pipe should not be closed")
+		case <-time.After(time.Millisecond):
+		}
+
+		assert.Equal(t, error1, pipe.First())
+	})
+}
+
+func TestWaitGroup_DrainPipe(t *testing.T) {
+	error1 := errors.New("error 1")
+	error2 := errors.New("error 2")
+	error3 := errors.New("error 3")
+
+	wg := NewWaitGroup()
+	pipe := NewPipe()
+	wg.DrainPipe(pipe)
+
+	wg.Add(1)
+	go func() {
+		wg.Done(error1)
+	}()
+
+	go func() {
+		pipe.Send(error2)
+		pipe.Send(error3)
+		pipe.Close()
+	}()
+
+	err := wg.Wait()
+	assert.ElementsMatch(t, []error{error1, error2, error3}, err.(*MultiError).Errors())
+}
+
+func TestWaitGroup_DrainPipeIgnore(t *testing.T) {
+	failure := errors.New("failure")
+
+	wg := NewWaitGroup(WaitGroupIgnore(context.Canceled))
+	pipe := NewPipe()
+	wg.DrainPipe(pipe)
+
+	pipe.Send(context.Canceled)
+	pipe.Send(failure)
+	pipe.Close()
+
+	err := wg.Wait()
+	assert.Equal(t, []error{failure}, err.(*MultiError).Errors())
+}
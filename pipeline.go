@@ -0,0 +1,148 @@
+package errors
+
+import "context"
+
+// Pipeline chain stages, the output of a stage is the input of the next one. each stage runs by its own
+// WaitGroup and is a named task of the pipeline, so Wait returns the errors of all stages.
+// an error in any stage cancels the pipeline context, so both the upstream and the downstream stages stop.
+//
+//	p := errors.NewPipeline(ctx)
+//	ids := errors.Source(p, "ids", []int{1, 2, 3})
+//	users := errors.Stage(p, "load", ids, 4, loadUser)
+//	errors.Sink(p, "save", users, 1, saveUser)
+//	err := p.Wait()
+type Pipeline struct {
+	group   *WaitGroup
+	options []WaitGroupOption
+}
+
+// NewPipeline create new Pipeline, the options are applied to the WaitGroup of the pipeline
+// and to the WaitGroups of stages (e.g. WaitGroupWithPanicRecovery recover the panics of stage workers).
+func NewPipeline(ctx context.Context, options ...WaitGroupOption) *Pipeline {
+	return &Pipeline{
+		group:   NewWaitGroup(append([]WaitGroupOption{WaitGroupWithContext(ctx)}, options...)...),
+		options: options,
+	}
+}
+
+// Context return the context of the pipeline, it's canceled by the first error of stages or by Stop.
+func (p *Pipeline) Context() context.Context {
+	return p.group.Context()
+}
+
+// Stop cancel the context of the pipeline.
+func (p *Pipeline) Stop() {
+	p.group.Stop()
+}
+
+// Wait wait for all stages and return their errors, the errors are wrapped with the name of the stage (see TaskOf).
+func (p *Pipeline) Wait() error {
+	return p.group.Wait()
+}
+
+// stop cancel the context of the pipeline with the error as cause.
+func (p *Pipeline) stop(err error) {
+	if p.group.cancel != nil {
+		p.group.cancel(err)
+	}
+}
+
+// Source add a stage that sends the items to the returned channel.
+func Source[T any](p *Pipeline, name string, items []T) <-chan T {
+	out := make(chan T)
+
+	p.group.DoNamed(p.Context(), name, func(ctx context.Context) error {
+		defer close(out)
+
+		for _, item := range items {
+			select {
+			case out <- item:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+
+		return nil
+	})
+
+	return out
+}
+
+// Stage add a stage that f is called for each item of in by workers goroutines,
+// and the results are sent to the returned channel.
+func Stage[In, Out any](p *Pipeline, name string, in <-chan In, workers int, f func(ctx context.Context, item In) (Out, error)) <-chan Out {
+	out := make(chan Out)
+
+	runStage(p, name, in, workers, func(ctx context.Context, item In) error {
+		value, err := f(ctx, item)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case out <- value:
+		case <-ctx.Done():
+		}
+
+		return nil
+	}, func() { close(out) })
+
+	return out
+}
+
+// Sink add a final stage that f is called for each item of in by workers goroutines.
+func Sink[T any](p *Pipeline, name string, in <-chan T, workers int, f func(ctx context.Context, item T) error) {
+	runStage(p, name, in, workers, f, func() {})
+}
+
+// runStage run the workers of a stage by a WaitGroup, done is called after all workers are done.
+func runStage[T any](p *Pipeline, name string, in <-chan T, workers int, f func(ctx context.Context, item T) error, done func()) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	// the context is the last option, so the stage is stopped with the pipeline.
+	options := append(p.options[:len(p.options):len(p.options)], WaitGroupWithContext(p.Context()))
+	stage := NewWaitGroup(options...)
+
+	for i := 0; i < workers; i++ {
+		stage.Do(func(ctx context.Context) error {
+			for ctx.Err() == nil {
+				var (
+					item T
+					ok   bool
+				)
+
+				select {
+				case item, ok = <-in:
+				case <-ctx.Done():
+					return nil
+				}
+
+				if !ok {
+					return nil
+				}
+
+				if err := f(ctx, item); err != nil {
+					p.stop(err)
+
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	p.group.DoNamed(p.Context(), name, func(ctx context.Context) error {
+		defer done()
+
+		// the errors that are not returned by f (e.g. recovered panics) stop the pipeline too.
+		err := stage.Wait()
+		if err != nil {
+			p.stop(err)
+		}
+
+		return err
+	})
+}
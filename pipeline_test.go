@@ -0,0 +1,105 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stages succeed, expect all items processed", func(t *testing.T) {
+		var (
+			mx     sync.Mutex
+			result []string
+		)
+
+		p := NewPipeline(context.Background())
+		numbers := Source(p, "numbers", []int{1, 2, 3, 4})
+		texts := Stage(p, "format", numbers, 2, func(ctx context.Context, item int) (string, error) {
+			return strconv.Itoa(item * 10), nil
+		})
+		Sink(p, "collect", texts, 1, func(ctx context.Context, item string) error {
+			mx.Lock()
+			defer mx.Unlock()
+
+			result = append(result, item)
+
+			return nil
+		})
+
+		assert.NoError(t, p.Wait())
+		assert.ElementsMatch(t, []string{"10", "20", "30", "40"}, result)
+		assert.ErrorIs(t, p.Context().Err(), context.Canceled)
+	})
+
+	t.Run("downstream stage failed, expect upstream stopped and stage error", func(t *testing.T) {
+		failure := errors.New("failure")
+		items := make([]int, 1000)
+
+		p := NewPipeline(context.Background())
+		numbers := Source(p, "numbers", items)
+		Sink(p, "save", numbers, 1, func(ctx context.Context, item int) error {
+			return failure
+		})
+
+		err := p.Wait()
+		assert.Equal(t, 1, err.(*MultiError).Len())
+		assert.Equal(t, failure, context.Cause(p.Context()))
+
+		name, _, ok := TaskOf(err.(*MultiError).errors[0])
+		assert.True(t, ok)
+		assert.Equal(t, "save", name)
+	})
+
+	t.Run("stage panics with panic recovery, expect panic error and pipeline stopped", func(t *testing.T) {
+		items := make([]int, 1000)
+
+		p := NewPipeline(context.Background(), WaitGroupWithPanicRecovery())
+		numbers := Source(p, "numbers", items)
+		Sink(p, "save", numbers, 1, func(ctx context.Context, item int) error {
+			panic("boom")
+		})
+
+		err := p.Wait()
+		assert.Equal(t, 1, err.(*MultiError).Len())
+		assert.True(t, IsPanic(err.(*MultiError).errors[0]))
+		assert.True(t, IsPanic(context.Cause(p.Context())))
+	})
+
+	t.Run("upstream stage failed, expect downstream stopped", func(t *testing.T) {
+		failure := errors.New("failure")
+		p := NewPipeline(context.Background())
+
+		numbers := Source(p, "numbers", []int{1, 2, 3})
+		texts := Stage(p, "format", numbers, 1, func(ctx context.Context, item int) (string, error) {
+			if item == 2 {
+				return "", failure
+			}
+
+			return strconv.Itoa(item), nil
+		})
+		Sink(p, "collect", texts, 1, func(ctx context.Context, item string) error { return nil })
+
+		err := p.Wait()
+		assert.Equal(t, 1, err.(*MultiError).Len())
+
+		name, _, _ := TaskOf(err.(*MultiError).errors[0])
+		assert.Equal(t, "format", name)
+	})
+
+	t.Run("pipeline is stopped, expect stages stopped without error", func(t *testing.T) {
+		p := NewPipeline(context.Background())
+		numbers := Source(p, "numbers", make([]int, 10))
+		p.Stop()
+
+		Sink(p, "collect", numbers, 1, func(ctx context.Context, item int) error { return nil })
+
+		assert.NoError(t, p.Wait())
+	})
+}
@@ -0,0 +1,39 @@
+package errors
+
+// Preset is a reusable bundle of fields, like the context of a DB call, defined once and stamped onto many errors.
+//
+//	var dbFields = errors.NewPreset(errors.String("db", "users"), errors.String("driver", "postgres"))
+//
+//	return errors.Wrap(err, "query user", dbFields.Fields(errors.String("id", id))...)
+type Preset struct {
+	fields []Field
+}
+
+// NewPreset create a new Preset, passed fields are copied.
+func NewPreset(fields ...Field) Preset {
+	return Preset{fields: copyFields(fields, 0)}
+}
+
+// Fields return the fields of the Preset followed by extra fields.
+// the returned slice is always a new copy, so it can be changed without changing the Preset.
+func (p Preset) Fields(extra ...Field) []Field {
+	return append(copyFields(p.fields, len(extra)), extra...)
+}
+
+// With return a new Preset with extra fields, p is not changed.
+func (p Preset) With(extra ...Field) Preset {
+	return Preset{fields: p.Fields(extra...)}
+}
+
+// Len return number of fields in the Preset.
+func (p Preset) Len() int {
+	return len(p.fields)
+}
+
+// copyFields return a copy of fields with room for extra fields.
+func copyFields(fields []Field, extra int) []Field {
+	copied := make([]Field, len(fields), len(fields)+extra)
+	copy(copied, fields)
+
+	return copied
+}
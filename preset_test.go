@@ -0,0 +1,50 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreset(t *testing.T) {
+	t.Parallel()
+
+	db := errors.String("db", "users")
+	driver := errors.String("driver", "postgres")
+	id := errors.String("id", "10")
+
+	t.Run("fields with extra, expect preset fields followed by extra", func(t *testing.T) {
+		preset := errors.NewPreset(db, driver)
+
+		assert.Equal(t, []errors.Field{db, driver, id}, preset.Fields(id))
+		assert.Equal(t, []errors.Field{db, driver}, preset.Fields())
+		assert.Equal(t, 2, preset.Len())
+	})
+
+	t.Run("returned fields are changed, expect preset not to change", func(t *testing.T) {
+		preset := errors.NewPreset(db, driver)
+
+		fields := preset.Fields()
+		fields[0] = id
+		_ = append(preset.Fields()[:1], id)
+
+		assert.Equal(t, []errors.Field{db, driver}, preset.Fields())
+	})
+
+	t.Run("with, expect new preset", func(t *testing.T) {
+		preset := errors.NewPreset(db)
+		extended := preset.With(driver)
+
+		assert.Equal(t, []errors.Field{db}, preset.Fields())
+		assert.Equal(t, []errors.Field{db, driver}, extended.Fields())
+	})
+
+	t.Run("used in error, expect fields in error", func(t *testing.T) {
+		preset := errors.NewPreset(db, driver)
+
+		err := errors.New("some error", preset.Fields(id)...)
+
+		assert.Equal(t, []errors.Field{db, driver, id}, errors.GetFields(err))
+	})
+}
@@ -0,0 +1,90 @@
+// Package prometheuserrors exports error statistics as Prometheus metrics, by an errors.Stater that increments a counter.
+package prometheuserrors
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mrsoftware/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Label names of the counter, the labels of errors.StatLabels are added after them.
+const (
+	LabelCode     = "code"
+	LabelSeverity = "severity"
+	LabelTemplate = "template"
+)
+
+// Stater is an errors.Stater that increments a counter labeled by the code, severity and template name of errors.
+// it's a prometheus.Collector, so it must be registered.
+//
+//	stater, err := prometheuserrors.NewStater(prometheus.CounterOpts{Name: "errors_total"}, nil)
+//	prometheus.MustRegister(stater)
+//	errors.SetDefaultStat(stater)
+type Stater struct {
+	counter *prometheus.CounterVec
+	labels  *errors.StatLabels
+}
+
+// labelNamePattern is the pattern of valid Prometheus label names.
+var labelNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// NewStater create new Stater, labels can be nil or add extra labels from the fields of errors.
+// an error with errors.CodeInvalidArgument is returned if a key of labels is not a valid Prometheus label name
+// (e.g. "http.method") or it's LabelCode, LabelSeverity or LabelTemplate.
+func NewStater(opts prometheus.CounterOpts, labels *errors.StatLabels) (*Stater, error) {
+	names := []string{LabelCode, LabelSeverity, LabelTemplate}
+	if labels != nil {
+		names = append(names, labels.Keys()...)
+	}
+
+	if err := validateLabelNames(names); err != nil {
+		return nil, err
+	}
+
+	return &Stater{counter: prometheus.NewCounterVec(opts, names), labels: labels}, nil
+}
+
+// validateLabelNames check the names are valid and unique label names, names starting with "__" are reserved.
+func validateLabelNames(names []string) error {
+	seen := make(map[string]struct{}, len(names))
+
+	for _, name := range names {
+		if !labelNamePattern.MatchString(name) || strings.HasPrefix(name, "__") {
+			return errors.New("invalid label name", errors.String("label", name), errors.CodeField(errors.CodeInvalidArgument))
+		}
+
+		if _, ok := seen[name]; ok {
+			return errors.New("duplicate label name", errors.String("label", name), errors.CodeField(errors.CodeInvalidArgument))
+		}
+
+		seen[name] = struct{}{}
+	}
+
+	return nil
+}
+
+// OnError increment the counter of the labels of err, nil errors are ignored.
+func (s *Stater) OnError(err error) {
+	if err == nil {
+		return
+	}
+
+	values := []string{string(errors.GetCode(err)), errors.GetSeverity(err).String(), errors.TemplateName(err)}
+	if s.labels != nil {
+		values = append(values, s.labels.Values(err)...)
+	}
+
+	s.counter.WithLabelValues(values...).Inc()
+}
+
+// Describe implements prometheus.Collector.
+func (s *Stater) Describe(descs chan<- *prometheus.Desc) {
+	s.counter.Describe(descs)
+}
+
+// Collect implements prometheus.Collector.
+func (s *Stater) Collect(metrics chan<- prometheus.Metric) {
+	s.counter.Collect(metrics)
+}
@@ -0,0 +1,56 @@
+package prometheuserrors_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/mrsoftware/errors/prometheuserrors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStater(t *testing.T) {
+	t.Parallel()
+
+	t.Run("errors are reported, expect counter incremented by labels", func(t *testing.T) {
+		opts := prometheus.CounterOpts{Name: "errors_total", Help: "Number of errors."}
+		stater, err := prometheuserrors.NewStater(opts, errors.NewStatLabels(10, "region"))
+		assert.NoError(t, err)
+
+		stater.OnError(errors.New("failure", errors.CodeField(errors.CodeNotFound), errors.String("region", "eu")))
+		stater.OnError(errors.New("failure", errors.CodeField(errors.CodeNotFound), errors.String("region", "eu")))
+		stater.OnError(errors.New("failure", errors.CodeField(errors.CodeInternal)))
+		stater.OnError(nil)
+
+		expected := `
+# HELP errors_total Number of errors.
+# TYPE errors_total counter
+errors_total{code="internal",region="",severity="error",template=""} 1
+errors_total{code="not_found",region="eu",severity="error",template=""} 2
+`
+
+		assert.NoError(t, testutil.CollectAndCompare(stater, strings.NewReader(expected)))
+	})
+
+	t.Run("label names are invalid or used by the stater, expect error", func(t *testing.T) {
+		opts := prometheus.CounterOpts{Name: "errors_total", Help: "Number of errors."}
+
+		for _, key := range []string{"http.method", "1region", "__region", prometheuserrors.LabelCode} {
+			stater, err := prometheuserrors.NewStater(opts, errors.NewStatLabels(10, key))
+
+			assert.Nil(t, stater, key)
+			assert.Equal(t, errors.CodeInvalidArgument, errors.GetCode(err), key)
+		}
+	})
+
+	t.Run("duplicate label keys, expect one label", func(t *testing.T) {
+		opts := prometheus.CounterOpts{Name: "errors_total", Help: "Number of errors."}
+
+		stater, err := prometheuserrors.NewStater(opts, errors.NewStatLabels(10, "region", "region"))
+
+		assert.NoError(t, err)
+		assert.NotNil(t, stater)
+	})
+}
@@ -0,0 +1,87 @@
+package errors
+
+// Result is a value or an error, useful for passing results over channels.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok create a successful Result.
+func Ok[T any](value T) Result[T] {
+	return Result[T]{value: value}
+}
+
+// Err create a failed Result, the passed fields are added to err (see AddFields).
+func Err[T any](err error, fields ...Field) Result[T] {
+	if err != nil && len(fields) != 0 {
+		err = AddFields(err, fields...)
+	}
+
+	return Result[T]{err: err}
+}
+
+// ResultOf create a Result from the common (value, error) pair.
+func ResultOf[T any](value T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+
+	return Ok(value)
+}
+
+// IsOk report whether the Result has no error.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// Err return the error of Result.
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// Unwrap return the value and error of Result.
+func (r Result[T]) Unwrap() (T, error) {
+	return r.value, r.err
+}
+
+// OrElse return the value of Result, or fallback if the Result has error.
+func (r Result[T]) OrElse(fallback T) T {
+	if r.err != nil {
+		return fallback
+	}
+
+	return r.value
+}
+
+// Map apply f to the value of a successful Result, the error of failed Result is kept.
+func Map[T, U any](r Result[T], f func(T) U) Result[U] {
+	if r.err != nil {
+		return Result[U]{err: r.err}
+	}
+
+	return Ok(f(r.value))
+}
+
+// SendResult send the (value, error) pair as a Result to the channel.
+func SendResult[T any](ch chan<- Result[T], value T, err error) {
+	ch <- ResultOf(value, err)
+}
+
+// CollectResults read results from the channel until it's closed,
+// and return the values of successful results and the MultiError of failed results.
+func CollectResults[T any](ch <-chan Result[T]) ([]T, error) {
+	values := make([]T, 0)
+	errs := NewMultiError()
+
+	for result := range ch {
+		if result.err != nil {
+			errs.Add(result.err)
+
+			continue
+		}
+
+		values = append(values, result.value)
+	}
+
+	return values, errs.Err()
+}
@@ -0,0 +1,58 @@
+package errors
+
+import (
+	"context"
+	"sync"
+)
+
+// errNotDone is the error of the results of tasks that are not done, like the panicked tasks.
+var errNotDone = New("task is not done")
+
+// ResultGroup is a WaitGroup for tasks that return a value, so the values are collected without
+// a mutex-protected slice alongside the WaitGroup.
+type ResultGroup[T any] struct {
+	group   *WaitGroup
+	mx      sync.Mutex
+	results []Result[T]
+}
+
+// NewResultGroup create new ResultGroup, the options are applied to its WaitGroup (see NewWaitGroup).
+func NewResultGroup[T any](options ...WaitGroupOption) *ResultGroup[T] {
+	return &ResultGroup[T]{group: NewWaitGroup(options...)}
+}
+
+// Do run f by the WaitGroup (see WaitGroup.Do) and record its value or error.
+func (g *ResultGroup[T]) Do(f func() (T, error)) {
+	g.mx.Lock()
+	index := len(g.results)
+	g.results = append(g.results, Err[T](errNotDone))
+	g.mx.Unlock()
+
+	g.group.Do(func(context.Context) error {
+		value, err := f()
+
+		g.mx.Lock()
+		g.results[index] = ResultOf(value, err)
+		g.mx.Unlock()
+
+		return err
+	})
+}
+
+// Wait wait for all tasks, the values of successful tasks are returned in the order of Do calls,
+// and the errors of failed tasks are returned as MultiError.
+func (g *ResultGroup[T]) Wait() ([]T, error) {
+	err := g.group.Wait()
+
+	g.mx.Lock()
+	defer g.mx.Unlock()
+
+	values := make([]T, 0, len(g.results))
+	for _, result := range g.results {
+		if result.IsOk() {
+			values = append(values, result.value)
+		}
+	}
+
+	return values, err
+}
@@ -0,0 +1,106 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultGroup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all tasks succeed, expect values in order of calls", func(t *testing.T) {
+		group := NewResultGroup[int]()
+
+		for i := 0; i < 5; i++ {
+			i := i
+			group.Do(func() (int, error) {
+				time.Sleep(time.Duration(5-i) * time.Millisecond)
+
+				return i, nil
+			})
+		}
+
+		values, err := group.Wait()
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, values)
+	})
+
+	t.Run("some tasks failed, expect values of successful tasks and errors", func(t *testing.T) {
+		failure := errors.New("failure")
+		group := NewResultGroup[string]()
+
+		group.Do(func() (string, error) { return "a", nil })
+		group.Do(func() (string, error) { return "", failure })
+		group.Do(func() (string, error) { return "c", nil })
+
+		values, err := group.Wait()
+		assert.ErrorIs(t, err, failure)
+		assert.Equal(t, []string{"a", "c"}, values)
+	})
+
+	t.Run("no task, expect empty values and no error", func(t *testing.T) {
+		values, err := NewResultGroup[int]().Wait()
+
+		assert.NoError(t, err)
+		assert.Empty(t, values)
+	})
+
+	t.Run("with error stacks option, expect error with stack", func(t *testing.T) {
+		group := NewResultGroup[int](WaitGroupWithErrorStacks(StacktraceFirst))
+		group.Do(func() (int, error) { return 0, errors.New("failure") })
+
+		_, err := group.Wait()
+		assert.NotEmpty(t, err.(*MultiError).errors[0].(*Error).stack)
+	})
+
+	t.Run("with panic recovery option, expect panic recorded as error", func(t *testing.T) {
+		group := NewResultGroup[int](WaitGroupWithPanicRecovery())
+		group.Do(func() (int, error) { return 1, nil })
+		group.Do(func() (int, error) { panic("boom") })
+
+		values, err := group.Wait()
+		assert.Equal(t, []int{1}, values)
+
+		var multi *MultiError
+		assert.True(t, errors.As(err, &multi))
+		assert.True(t, IsPanic(multi.Errors()[0]))
+	})
+
+	t.Run("with task limit option, expect tasks limited", func(t *testing.T) {
+		var running, maxRunning int32
+		group := NewResultGroup[int](WaitGroupWithTaskLimit(1))
+
+		for i := 0; i < 5; i++ {
+			group.Do(func() (int, error) {
+				current := atomic.AddInt32(&running, 1)
+				if current > atomic.LoadInt32(&maxRunning) {
+					atomic.StoreInt32(&maxRunning, current)
+				}
+
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&running, -1)
+
+				return 0, nil
+			})
+		}
+
+		values, err := group.Wait()
+		assert.NoError(t, err)
+		assert.Len(t, values, 5)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&maxRunning))
+	})
+
+	t.Run("with ignore option, expect ignored errors dropped", func(t *testing.T) {
+		group := NewResultGroup[int](WaitGroupIgnore(context.Canceled))
+		group.Do(func() (int, error) { return 0, context.Canceled })
+
+		values, err := group.Wait()
+		assert.NoError(t, err)
+		assert.Empty(t, values)
+	})
+}
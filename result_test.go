@@ -0,0 +1,59 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"strconv"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResult(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ok result, expect value", func(t *testing.T) {
+		result := errors.Ok(10)
+
+		value, err := result.Unwrap()
+		assert.True(t, result.IsOk())
+		assert.Equal(t, 10, value)
+		assert.Nil(t, err)
+		assert.Equal(t, 10, result.OrElse(20))
+	})
+
+	t.Run("err result, expect error with fields", func(t *testing.T) {
+		cause := stdErrors.New("some error")
+		result := errors.Err[int](cause, errors.String("id", "10"))
+
+		value, err := result.Unwrap()
+		assert.False(t, result.IsOk())
+		assert.Equal(t, 0, value)
+		assert.True(t, errors.Is(err, cause))
+		assert.Equal(t, "10", errors.GetField(err, "id").Value())
+		assert.Equal(t, 20, result.OrElse(20))
+	})
+
+	t.Run("map, expect to apply only on ok result", func(t *testing.T) {
+		cause := stdErrors.New("some error")
+
+		assert.Equal(t, errors.Ok("10"), errors.Map(errors.Ok(10), strconv.Itoa))
+		assert.Equal(t, cause, errors.Map(errors.ResultOf(0, cause), strconv.Itoa).Err())
+	})
+}
+
+func TestCollectResults(t *testing.T) {
+	t.Parallel()
+
+	cause := stdErrors.New("some error")
+	ch := make(chan errors.Result[int], 3)
+
+	errors.SendResult(ch, 1, nil)
+	errors.SendResult(ch, 0, cause)
+	errors.SendResult(ch, 3, nil)
+	close(ch)
+
+	values, err := errors.CollectResults(ch)
+	assert.Equal(t, []int{1, 3}, values)
+	assert.True(t, errors.Is(err, cause))
+}
@@ -0,0 +1,33 @@
+package errors
+
+import "time"
+
+// MarkRetryable mark the error as retryable (transient), the mark survive wrapping (see IsRetryable).
+func MarkRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return AddFields(err, Bool(KeyRetryable, true))
+}
+
+// RetryableAfter is like MarkRetryable, but also set the delay that the caller should wait before retry (see RetryAfter).
+func RetryableAfter(err error, delay time.Duration) error {
+	if err == nil {
+		return nil
+	}
+
+	return AddFields(err, Bool(KeyRetryable, true), Duration(KeyRetryAfter, delay))
+}
+
+// IsRetryable report whether the error is marked as retryable in its chain.
+func IsRetryable(err error) bool {
+	retryable, _ := FindFieldInChain(KeyRetryable, err).BoolValue()
+
+	return retryable
+}
+
+// RetryAfter return the delay set by RetryableAfter in error chain, false is returned if there is no delay.
+func RetryAfter(err error) (time.Duration, bool) {
+	return FindFieldInChain(KeyRetryAfter, err).DurationValue()
+}
@@ -0,0 +1,49 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error is marked, expect retryable after wrapping", func(t *testing.T) {
+		err := errors.MarkRetryable(stdErrors.New("connection reset"))
+		err = fmt.Errorf("call service: %w", errors.Wrap(err, "query user"))
+
+		assert.True(t, errors.IsRetryable(err))
+
+		_, ok := errors.RetryAfter(err)
+		assert.False(t, ok)
+	})
+
+	t.Run("error is not marked, expect not retryable", func(t *testing.T) {
+		assert.False(t, errors.IsRetryable(errors.New("invalid input")))
+		assert.False(t, errors.IsRetryable(nil))
+		assert.Nil(t, errors.MarkRetryable(nil))
+	})
+}
+
+func TestRetryableAfter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error is marked with delay, expect retryable with delay", func(t *testing.T) {
+		err := errors.Wrap(errors.RetryableAfter(errors.New("too many requests"), time.Second), "call service")
+
+		delay, ok := errors.RetryAfter(err)
+
+		assert.True(t, errors.IsRetryable(err))
+		assert.True(t, ok)
+		assert.Equal(t, time.Second, delay)
+	})
+
+	t.Run("nil error, expect nil", func(t *testing.T) {
+		assert.Nil(t, errors.RetryableAfter(nil, time.Second))
+	})
+}
@@ -0,0 +1,39 @@
+package errors
+
+import "sync/atomic"
+
+// Redacted is the value of Secret fields when redaction is enabled.
+const Redacted = "[REDACTED]"
+
+var disableRedaction int32
+
+// SetRedaction control whether the value of Secret fields is redacted in formatting, JSON and logger adapters,
+// it's enabled by default and must not be disabled in production.
+func SetRedaction(enabled bool) {
+	var value int32
+	if !enabled {
+		value = 1
+	}
+
+	atomic.StoreInt32(&disableRedaction, value)
+}
+
+// RedactionEnabled report whether the value of Secret fields is redacted.
+func RedactionEnabled() bool {
+	return atomic.LoadInt32(&disableRedaction) == 0
+}
+
+// Secret constructs a field that carries sensitive data, Value() of the field is Redacted (see SetRedaction),
+// the raw value is only available by Unredacted.
+func Secret(key string, value interface{}) Field {
+	return Field{Key: key, Type: FieldTypeSecret, Interface: value}
+}
+
+// Unredacted return the raw value of the field, even for Secret fields, for in-process use only.
+func (f Field) Unredacted() interface{} {
+	if f.Type == FieldTypeSecret {
+		return f.Interface
+	}
+
+	return f.Value()
+}
@@ -0,0 +1,48 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecret(t *testing.T) {
+	t.Run("redaction is enabled, expect redacted value in all outputs", func(t *testing.T) {
+		err := errors.New("login failed", errors.Secret("password", "p@ss"))
+		field := errors.GetField(err, "password")
+
+		assert.Equal(t, errors.Redacted, field.Value())
+		assert.Equal(t, "p@ss", field.Unredacted())
+		assert.Equal(t, "Secret", field.Type.String())
+		assert.Equal(t, "login failed: [{Key: password, Value: [REDACTED]}]", fmt.Sprintf("%v", err))
+		assert.Equal(t, "login failed: [{Key: password, Type: Secret, Value: [REDACTED]}]", fmt.Sprintf("%+v", err))
+		assert.Contains(t, []slog.Attr{slog.String("password", errors.Redacted)}, errors.SlogAttrs(err)[1])
+
+		recorder := httptest.NewRecorder()
+		require.NoError(t, errors.WriteHTTPError(recorder, err))
+
+		var body errors.HTTPErrorBody
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Equal(t, errors.Redacted, body.Fields["password"])
+	})
+
+	t.Run("redaction is disabled, expect raw value", func(t *testing.T) {
+		errors.SetRedaction(false)
+		defer errors.SetRedaction(true)
+
+		field := errors.Secret("password", "p@ss")
+
+		assert.False(t, errors.RedactionEnabled())
+		assert.Equal(t, "p@ss", field.Value())
+	})
+
+	t.Run("not secret field, expect its value", func(t *testing.T) {
+		assert.Equal(t, "value", errors.String("key", "value").Unredacted())
+	})
+}
@@ -0,0 +1,95 @@
+package errors
+
+import "log/slog"
+
+// Severity is the level of an error, it lets logging middleware decide the log level from the error itself.
+type Severity int
+
+const (
+	// SeverityDebug is used for errors that are only interesting while debugging.
+	SeverityDebug Severity = iota + 1
+
+	// SeverityInfo is used for expected errors, like invalid input.
+	SeverityInfo
+
+	// SeverityWarning is used for errors that may need attention.
+	SeverityWarning
+
+	// SeverityError is used for errors that need attention, it's the severity of errors without severity.
+	SeverityError
+
+	// SeverityCritical is used for errors that need immediate attention.
+	SeverityCritical
+)
+
+var severityNames = map[Severity]string{
+	SeverityDebug:    "debug",
+	SeverityInfo:     "info",
+	SeverityWarning:  "warning",
+	SeverityError:    "error",
+	SeverityCritical: "critical",
+}
+
+// String version of Severity.
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// SlogLevel return the slog.Level of the severity, SeverityCritical is logged one level above slog.LevelError.
+func (s Severity) SlogLevel() slog.Level {
+	switch s {
+	case SeverityDebug:
+		return slog.LevelDebug
+	case SeverityInfo:
+		return slog.LevelInfo
+	case SeverityWarning:
+		return slog.LevelWarn
+	case SeverityCritical:
+		return slog.LevelError + 1
+	case SeverityError:
+		fallthrough
+	default:
+		return slog.LevelError
+	}
+}
+
+// SeverityField constructs a field that carries the Severity.
+func SeverityField(severity Severity) Field {
+	return String(KeySeverity, severity.String())
+}
+
+// NewWithSeverity is like New, but the severity is also added.
+func NewWithSeverity(msg string, severity Severity, fields ...Field) error {
+	// use a full slice expression, so we never write to the caller array.
+	return wrap(1, nil, msg, append(fields[:len(fields):len(fields)], SeverityField(severity))) // skip NewWithSeverity
+}
+
+// WithSeverity return the passed error with the severity, it overrides the severity of the chain
+// and the passed error is not changed.
+func WithSeverity(err error, severity Severity) error {
+	if err == nil {
+		return nil
+	}
+
+	return withField(1, err, SeverityField(severity)) // skip WithSeverity
+}
+
+// GetSeverity find the severity in error chain, SeverityError is returned if there is no severity.
+func GetSeverity(err error) Severity {
+	value, ok := FindFieldInChain(KeySeverity, err).StringValue()
+	if !ok {
+		return SeverityError
+	}
+
+	for severity, name := range severityNames {
+		if name == value {
+			return severity
+		}
+	}
+
+	return SeverityError
+}
@@ -0,0 +1,58 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"log/slog"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSeverity(t *testing.T) {
+	t.Parallel()
+
+	t.Run("severity is set in chain, expect to find it", func(t *testing.T) {
+		cause := errors.NewWithSeverity("invalid input", errors.SeverityInfo, errors.String("id", "1"))
+		err := errors.Wrap(cause, "some error")
+
+		assert.Equal(t, errors.SeverityInfo, errors.GetSeverity(err))
+		assert.Equal(t, []errors.Field{errors.String("id", "1"), errors.SeverityField(errors.SeverityInfo)}, errors.GetFields(cause))
+	})
+
+	t.Run("severity is added, expect to find it", func(t *testing.T) {
+		err := errors.WithSeverity(stdErrors.New("some error"), errors.SeverityCritical)
+
+		assert.Equal(t, errors.SeverityCritical, errors.GetSeverity(err))
+	})
+
+	t.Run("severity is set again, expect the severity overridden and the cause not changed", func(t *testing.T) {
+		cause := errors.NewWithSeverity("some error", errors.SeverityCritical)
+		err := errors.WithSeverity(cause, errors.SeverityInfo)
+
+		assert.Equal(t, errors.SeverityInfo, errors.GetSeverity(err))
+		assert.Equal(t, errors.SeverityCritical, errors.GetSeverity(cause))
+	})
+
+	t.Run("no severity in chain, expect error severity", func(t *testing.T) {
+		assert.Equal(t, errors.SeverityError, errors.GetSeverity(stdErrors.New("some error")))
+		assert.Equal(t, errors.SeverityError, errors.GetSeverity(nil))
+		assert.Nil(t, errors.WithSeverity(nil, errors.SeverityDebug))
+	})
+
+	t.Run("wrap keep, expect severity to be inherited", func(t *testing.T) {
+		err := errors.WrapKeep(errors.NewWithSeverity("cause", errors.SeverityWarning), "some error")
+
+		assert.Equal(t, []errors.Field{errors.SeverityField(errors.SeverityWarning)}, errors.GetFields(err))
+	})
+}
+
+func TestSeverity(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "warning", errors.SeverityWarning.String())
+	assert.Equal(t, "unknown", errors.Severity(0).String())
+	assert.Equal(t, slog.LevelWarn, errors.SeverityWarning.SlogLevel())
+	assert.Equal(t, slog.LevelError, errors.Severity(0).SlogLevel())
+	assert.True(t, errors.SeverityCritical.SlogLevel() > slog.LevelError)
+}
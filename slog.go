@@ -0,0 +1,84 @@
+package errors
+
+import (
+	"log/slog"
+	"time"
+)
+
+// KeyMessage is the slog attribute key used to store the error message, see SlogAttrs.
+const KeyMessage = "message"
+
+// LogValue implement slog.LogValuer, so the error is logged as a group of its message and the fields of the chain.
+func (e *Error) LogValue() slog.Value {
+	return slog.GroupValue(SlogAttrs(e)...)
+}
+
+// SlogAttrs convert the error to slog attributes, the message and the fields of the chain are included.
+// repeated keys in the chain are handled by the policy of GetDedupPolicy.
+func SlogAttrs(err error) []slog.Attr {
+	if err == nil {
+		return nil
+	}
+
+	fields := DedupChainFields(err)
+	attrs := make([]slog.Attr, 0, len(fields)+1)
+	attrs = append(attrs, slog.String(KeyMessage, err.Error()))
+
+	for _, field := range fields {
+		if field.Type != FieldTypeContext {
+			attrs = append(attrs, field.SlogAttr())
+		}
+	}
+
+	return attrs
+}
+
+// SlogAttr convert the field to slog attribute.
+func (f Field) SlogAttr() slog.Attr {
+	switch f.Type { // nolint: exhaustive
+	case FieldTypeString:
+		return slog.String(f.Key, f.Str)
+	case FieldTypeInt64, FieldTypeInt32, FieldTypeInt16, FieldTypeInt8:
+		return slog.Int64(f.Key, f.Integer)
+	case FieldTypeUint64, FieldTypeUint32, FieldTypeUintptr:
+		return slog.Uint64(f.Key, uint64(f.Integer))
+	case FieldTypeFloat64, FieldTypeFloat32:
+		value, _ := f.Float64Value()
+
+		return slog.Float64(f.Key, value)
+	case FieldTypeBool:
+		value, _ := f.BoolValue()
+
+		return slog.Bool(f.Key, value)
+	case FieldTypeTime, FieldTypeTimeFull:
+		value, _ := f.TimeValue()
+
+		return slog.Time(f.Key, value)
+	case FieldTypeDuration:
+		return slog.Duration(f.Key, time.Duration(f.Integer))
+	case FieldTypeByteString:
+		value, _ := f.StringValue()
+
+		return slog.String(f.Key, value)
+	case FieldTypeNamespace:
+		fields, _ := f.NamespaceValue()
+		attrs := make([]interface{}, 0, len(fields))
+
+		for _, field := range fields {
+			if field.Type != FieldTypeContext {
+				attrs = append(attrs, field.SlogAttr())
+			}
+		}
+
+		return slog.Group(f.Key, attrs...)
+	case FieldTypeError:
+		value, _ := f.ErrorValue()
+		if value == nil {
+			return slog.Any(f.Key, nil)
+		}
+
+		return slog.String(f.Key, value.Error())
+	default:
+		return slog.Any(f.Key, f.Value())
+	}
+}
@@ -0,0 +1,62 @@
+package errors_test
+
+import (
+	"bytes"
+	"encoding/json"
+	stdErrors "errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlogAttrs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error has fields in chain, expect message and typed attributes", func(t *testing.T) {
+		cause := errors.New("cause", errors.String("id", "1"), errors.Int("attempt", 2))
+		err := errors.Wrap(cause, "some error", errors.String("id", "2"), errors.Duration("took", time.Second))
+
+		assert.Equal(t, []slog.Attr{
+			slog.String("message", "some error: cause"),
+			slog.String("id", "2"),
+			slog.Duration("took", time.Second),
+			slog.Int64("attempt", 2),
+		}, errors.SlogAttrs(err))
+	})
+
+	t.Run("nil error, expect nil", func(t *testing.T) {
+		assert.Nil(t, errors.SlogAttrs(nil))
+	})
+}
+
+func TestError_LogValue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("log error with slog, expect structured fields", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		logger := slog.New(slog.NewJSONHandler(buffer, nil))
+
+		err := errors.Wrap(stdErrors.New("cause"), "some error", errors.CodeField(errors.CodeNotFound), errors.Float64("ratio", 0.5))
+		logger.Error("failed", "err", err)
+
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(buffer.Bytes(), &record))
+
+		assert.Equal(t, map[string]interface{}{"message": "some error: cause", "code": "not_found", "ratio": 0.5}, record["err"])
+	})
+}
+
+func TestField_SlogAttr(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	assert.Equal(t, slog.Bool("key", true), errors.Bool("key", true).SlogAttr())
+	assert.Equal(t, slog.Time("key", now), errors.Time("key", now).SlogAttr())
+	assert.Equal(t, slog.String("key", "value"), errors.ByteString("key", []byte("value")).SlogAttr())
+	assert.Equal(t, slog.String("key", "cause"), errors.NamedError("key", stdErrors.New("cause")).SlogAttr())
+}
@@ -5,13 +5,16 @@ import (
 	"fmt"
 	"runtime"
 	"sync"
+	"sync/atomic"
 )
 
 var (
 	stacktracePool = sync.Pool{
 		New: func() interface{} {
+			atomic.AddUint64(&metrics.StackPoolMisses, 1)
+
 			return &stacktrace{
-				storage: make([]uintptr, 64),
+				storage: make([]uintptr, defaultStackStorage),
 			}
 		},
 	}
@@ -41,29 +44,110 @@ const (
 	// StacktraceFull captures the entire call stack, allocating more
 	// storage for it if needed.
 	StacktraceFull
+
+	// StacktraceNone captures no stack.
+	StacktraceNone StacktraceDepth = -1
 )
 
+var stackDepth = int32(StacktraceNone)
+
+// SetStackDepth set the depth of the stack that New, Wrap and other constructors capture,
+// it's StacktraceNone by default, since capturing the stack is relatively expensive.
+func SetStackDepth(depth StacktraceDepth) {
+	atomic.StoreInt32(&stackDepth, int32(depth))
+}
+
+// GetStackDepth return the depth of the stack that constructors capture.
+func GetStackDepth() StacktraceDepth {
+	return StacktraceDepth(atomic.LoadInt32(&stackDepth))
+}
+
+// captureStack captures program counters of the stack, skip=0 identifies the caller of captureStack.
+func captureStack(skip int, depth StacktraceDepth) []uintptr {
+	if depth == StacktraceNone {
+		return nil
+	}
+
+	stack := captureStacktrace(skip+1, depth)
+	defer stack.Free()
+
+	pcs := make([]uintptr, len(stack.pcs))
+	copy(pcs, stack.pcs)
+
+	return pcs
+}
+
+// formatStack formats the program counters as the stacktrace string.
+func formatStack(pcs []uintptr) string {
+	if len(pcs) == 0 {
+		return ""
+	}
+
+	buffer := &bytes.Buffer{}
+
+	stackfmt := newStackFormatter(buffer)
+	stackfmt.FormatStack(&stacktrace{pcs: pcs, frames: runtime.CallersFrames(pcs)})
+
+	return buffer.String()
+}
+
+// formatStackElided is like formatStack, but the frames that are shared with the shown stack
+// (the common callers at the bottom of both stacks) are elided.
+func formatStackElided(pcs []uintptr, shown []uintptr) string {
+	common := 0
+	for common < len(pcs) && common < len(shown) && pcs[len(pcs)-1-common] == shown[len(shown)-1-common] {
+		common++
+	}
+
+	if common == 0 {
+		return formatStack(pcs)
+	}
+
+	elided := 0
+
+	stack := &stacktrace{pcs: pcs[len(pcs)-common:], frames: runtime.CallersFrames(pcs[len(pcs)-common:])}
+	stack.each(stackOptions{}, func(runtime.Frame) { elided++ })
+
+	trace := formatStack(pcs[:len(pcs)-common])
+	if elided == 0 {
+		return trace
+	}
+
+	if trace != "" {
+		trace += "\n"
+	}
+
+	return trace + fmt.Sprintf("... %d frames elided ...", elided)
+}
+
 // captureStacktrace captures a stack trace of the specified depth, skipping
 // the provided number of frames. skip=0 identifies the caller of
 // captureStacktrace.
 //
 // The caller must call Free on the returned stacktrace after using it.
 func captureStacktrace(skip int, depth StacktraceDepth) *stacktrace {
+	atomic.AddUint64(&metrics.StackCaptures, 1)
+
 	stack := stacktracePool.Get().(*stacktrace) // nolint: forcetypeassert
 
-	switch depth {
-	case StacktraceFirst:
+	switch {
+	case depth == StacktraceFirst:
 		stack.pcs = stack.storage[:1]
-	case StacktraceFull:
+	case depth == StacktraceFull:
 		stack.pcs = stack.storage
+	case depth < 0: // StacktraceNone or invalid depth.
+		stack.pcs = stack.storage[:0]
 	default:
+		if int(depth) > len(stack.storage) {
+			stack.storage = make([]uintptr, depth)
+		}
+
 		stack.pcs = stack.storage[:depth]
 	}
 
-	// Unlike other "skip"-based APIs, skip=0 identifies runtime.Callers
-	// itself. +2 to skip captureStacktrace and runtime.Callers.
-	numFrames := runtime.Callers(
-		skip+2,
+	// +1 to skip captureStacktrace.
+	numFrames := callers(
+		skip+1,
 		stack.pcs,
 	)
 
@@ -74,7 +158,7 @@ func captureStacktrace(skip int, depth StacktraceDepth) *stacktrace {
 		pcs := stack.pcs
 		for numFrames == len(pcs) {
 			pcs = make([]uintptr, len(pcs)*2)
-			numFrames = runtime.Callers(skip+2, pcs)
+			numFrames = callers(skip+1, pcs)
 		}
 
 		// Discard old storage instead of returning it to the pool.
@@ -128,28 +212,91 @@ func TakeStacktraceDepth(skip int, depth StacktraceDepth) string {
 	return buffer.String()
 }
 
+// TakeStacktraceOptions is like TakeStacktraceDepth, but the options are used instead of the options of SetStackOptions.
+func TakeStacktraceOptions(skip int, depth StacktraceDepth, options ...StackOption) string {
+	stack := captureStacktrace(skip+1, depth)
+	defer stack.Free()
+
+	buffer := &bytes.Buffer{}
+
+	stackfmt := stackFormatter{b: buffer, options: newStackOptions(options)}
+	stackfmt.FormatStack(stack)
+
+	return buffer.String()
+}
+
+// takeAllStacktraces return the stacktraces of all goroutines, truncated to size bytes if size is positive.
+func takeAllStacktraces(size int) string {
+	bufferSize := 64 << 10
+	if size > 0 {
+		bufferSize = size + 1 // +1 to know if it's truncated.
+	}
+
+	for {
+		buffer := make([]byte, bufferSize)
+
+		n := runtime.Stack(buffer, true)
+		if n < len(buffer) {
+			return string(buffer[:n])
+		}
+
+		if size > 0 {
+			return truncateString(string(buffer[:n]), size) + TruncationMarker
+		}
+
+		bufferSize *= 2
+	}
+}
+
 // stackFormatter formats a stack trace into a readable string representation.
 type stackFormatter struct {
 	b        *bytes.Buffer
 	nonEmpty bool // whehther we've written at least one frame already
+	options  stackOptions
 }
 
-// newStackFormatter builds a new stackFormatter.
+// newStackFormatter builds a new stackFormatter with the options of SetStackOptions.
 func newStackFormatter(b *bytes.Buffer) stackFormatter {
-	return stackFormatter{b: b}
+	return stackFormatter{b: b, options: getStackOptions()}
 }
 
 // FormatStack formats all remaining frames in the provided stacktrace -- minus
 // the final runtime.main/runtime.goexit frame.
 func (sf *stackFormatter) FormatStack(stack *stacktrace) {
+	stack.each(sf.options, sf.FormatFrame)
+}
+
+// each call fn for the remaining frames that are not filtered by the options,
+// minus the final runtime.main/runtime.goexit frame.
+func (st *stacktrace) each(options stackOptions, fn func(frame runtime.Frame)) {
+	count := 0
+
 	// Note: On the last iteration, frames.Next() returns false, with a valid
-	// frame, but we ignore this frame. The last frame is a runtime frame which
-	// adds noise, since it's only either runtime.main or runtime.goexit.
-	for frame, more := stack.Next(); more; frame, more = stack.Next() {
-		sf.FormatFrame(frame)
+	// frame. For the full stack it's a runtime frame which adds noise, since
+	// it's only either runtime.main or runtime.goexit, so we ignore it. But if
+	// the stack is truncated by depth, the last frame is a real frame.
+	for frame, more := st.Next(); ; frame, more = st.Next() {
+		if options.maxFrames > 0 && count >= options.maxFrames {
+			return
+		}
+
+		if (more || !isRuntimeExitFrame(frame)) && !options.skip(frame.Function) {
+			count++
+
+			fn(frame)
+		}
+
+		if !more {
+			return
+		}
 	}
 }
 
+// isRuntimeExitFrame report if frame is the final runtime.main/runtime.goexit frame, or no frame at all.
+func isRuntimeExitFrame(frame runtime.Frame) bool {
+	return frame.Function == "" || frame.Function == "runtime.main" || frame.Function == "runtime.goexit"
+}
+
 // FormatFrame formats the given frame.
 func (sf *stackFormatter) FormatFrame(frame runtime.Frame) {
 	if sf.nonEmpty {
@@ -160,7 +307,38 @@ func (sf *stackFormatter) FormatFrame(frame runtime.Frame) {
 	sf.b.WriteString(frame.Function)
 	sf.b.WriteByte('\n')
 	sf.b.WriteByte('\t')
-	sf.b.WriteString(frame.File)
+	sf.b.WriteString(sf.options.trim(frame.File))
 	sf.b.WriteByte(':')
 	fmt.Fprint(sf.b, frame.Line)
+
+	if sf.options.sourceLines > 0 {
+		sf.formatSource(frame)
+	}
+}
+
+// formatSource formats the source lines around the line of the frame, the line of the frame is marked with '>'.
+func (sf *stackFormatter) formatSource(frame runtime.Frame) {
+	lines := sourceLines(frame.File)
+	if frame.Line <= 0 || frame.Line > len(lines) {
+		return
+	}
+
+	first := frame.Line - sf.options.sourceLines
+	if first < 1 {
+		first = 1
+	}
+
+	last := frame.Line + sf.options.sourceLines
+	if last > len(lines) {
+		last = len(lines)
+	}
+
+	for line := first; line <= last; line++ {
+		marker := ' '
+		if line == frame.Line {
+			marker = '>'
+		}
+
+		fmt.Fprintf(sf.b, "\n\t\t%c%5d: %s", marker, line, lines[line-1])
+	}
 }
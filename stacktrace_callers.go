@@ -0,0 +1,10 @@
+//go:build !tinygo
+
+package errors
+
+import "runtime"
+
+// callers is runtime.Callers, skip=0 identifies the caller of callers.
+func callers(skip int, pcs []uintptr) int {
+	return runtime.Callers(skip+2, pcs)
+}
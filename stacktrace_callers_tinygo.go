@@ -0,0 +1,9 @@
+//go:build tinygo
+
+package errors
+
+// callers does not capture any frame under TinyGo, since runtime.Callers is not supported there,
+// so stacktraces are empty instead of failing.
+func callers(int, []uintptr) int {
+	return 0
+}
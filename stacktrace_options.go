@@ -0,0 +1,105 @@
+package errors
+
+import (
+	"strings"
+	"sync"
+)
+
+// StackOption configure how stacktraces are formatted, see SetStackOptions.
+type StackOption func(o *stackOptions)
+
+type stackOptions struct {
+	skipPackages []string
+	maxFrames    int
+	trimPaths    []string
+	sourceLines  int
+}
+
+// StackSkipPackages skip the frames of functions in the packages, e.g. "runtime" or "github.com/mrsoftware/errors".
+// sub packages are not skipped.
+func StackSkipPackages(packages ...string) StackOption {
+	return func(o *stackOptions) {
+		o.skipPackages = append(o.skipPackages, packages...)
+	}
+}
+
+// StackSkipInternal skip the frames of runtime, testing and this package.
+func StackSkipInternal() StackOption {
+	return StackSkipPackages("runtime", "testing", "github.com/mrsoftware/errors")
+}
+
+// StackMaxFrames limit the stacktrace to n frames, zero means no limit.
+func StackMaxFrames(n int) StackOption {
+	return func(o *stackOptions) {
+		o.maxFrames = n
+	}
+}
+
+// StackTrimPaths trim the prefixes (e.g. GOPATH or the module root) from the file paths of frames.
+func StackTrimPaths(prefixes ...string) StackOption {
+	return func(o *stackOptions) {
+		o.trimPaths = append(o.trimPaths, prefixes...)
+	}
+}
+
+// StackSourceLines include n lines of the source code before and after the line of each frame,
+// like Sentry does. the source files are read on formatting, so it must only be used in development.
+func StackSourceLines(n int) StackOption {
+	return func(o *stackOptions) {
+		o.sourceLines = n
+	}
+}
+
+var (
+	globalStackOptionsMx sync.RWMutex
+	globalStackOptions   stackOptions
+)
+
+// SetStackOptions set the options that are used to format all stacktraces, it replaces the previous options.
+// the options are applied on formatting, so they apply to already captured stacks too.
+func SetStackOptions(options ...StackOption) {
+	opts := newStackOptions(options)
+
+	globalStackOptionsMx.Lock()
+	defer globalStackOptionsMx.Unlock()
+
+	globalStackOptions = opts
+}
+
+func getStackOptions() stackOptions {
+	globalStackOptionsMx.RLock()
+	defer globalStackOptionsMx.RUnlock()
+
+	return globalStackOptions
+}
+
+func newStackOptions(options []StackOption) stackOptions {
+	opts := stackOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	return opts
+}
+
+// skip report if the frame of function must be skipped.
+func (o stackOptions) skip(function string) bool {
+	for _, pkg := range o.skipPackages {
+		if strings.HasPrefix(function, pkg+".") && !strings.Contains(function[len(pkg)+1:], "/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// trim return the file without the first matched prefix.
+func (o stackOptions) trim(file string) string {
+	for _, prefix := range o.trimPaths {
+		if strings.HasPrefix(file, prefix) {
+			return strings.TrimPrefix(strings.TrimPrefix(file, prefix), "/")
+		}
+	}
+
+	return file
+}
@@ -0,0 +1,27 @@
+package errors
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// sourceCache caches the lines of source files, a file that can not be read is cached as nil.
+var sourceCache sync.Map
+
+// sourceLines return the lines of the source file.
+func sourceLines(file string) []string {
+	if lines, ok := sourceCache.Load(file); ok {
+		return lines.([]string) // nolint: forcetypeassert
+	}
+
+	var lines []string
+
+	if content, err := os.ReadFile(file); err == nil {
+		lines = strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+	}
+
+	sourceCache.Store(file, lines)
+
+	return lines
+}
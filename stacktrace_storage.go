@@ -0,0 +1,6 @@
+//go:build !wasm && !tinygo
+
+package errors
+
+// defaultStackStorage is the initial storage size of pooled stacktraces.
+const defaultStackStorage = 64
@@ -0,0 +1,7 @@
+//go:build wasm || tinygo
+
+package errors
+
+// defaultStackStorage is the initial storage size of pooled stacktraces,
+// WASM and TinyGo targets have a small memory, so a smaller storage is used.
+const defaultStackStorage = 16
@@ -3,6 +3,8 @@ package errors
 
 import (
 	"bytes"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -85,3 +87,103 @@ func withStackDepth(depth int, f func()) {
 	}
 	recurse(rune(depth))
 }
+
+func TestTakeStacktraceDepthLargerThanStorage(t *testing.T) {
+	trace := TakeStacktraceDepth(0, StacktraceDepth(defaultStackStorage*2))
+	lines := strings.Split(trace, "\n")
+	require.NotEmpty(t, lines, "Expected stacktrace to have at least one frame.")
+	assert.Contains(t, lines[0], "github.com/mrsoftware/errors.TestTakeStacktraceDepthLargerThanStorage")
+}
+
+func TestTakeStacktraceDepthNone(t *testing.T) {
+	t.Run("none depth, expect empty stacktrace", func(t *testing.T) {
+		assert.Empty(t, TakeStacktraceDepth(0, StacktraceNone))
+	})
+
+	t.Run("negative depth, expect empty stacktrace", func(t *testing.T) {
+		assert.Empty(t, TakeStacktraceDepth(0, StacktraceDepth(-5)))
+	})
+}
+
+func TestTakeStacktraceOptions(t *testing.T) {
+	t.Run("skip packages, expect no frame of the packages", func(t *testing.T) {
+		trace := TakeStacktraceOptions(0, StacktraceFull, StackSkipInternal())
+
+		assert.NotContains(t, trace, "testing.tRunner")
+		assert.NotContains(t, trace, "github.com/mrsoftware/errors.TestTakeStacktraceOptions")
+	})
+
+	t.Run("max frames, expect limited frames", func(t *testing.T) {
+		trace := TakeStacktraceOptions(0, StacktraceFull, StackMaxFrames(1))
+
+		lines := strings.Split(trace, "\n")
+		assert.Len(t, lines, 2)
+		assert.Contains(t, lines[0], "github.com/mrsoftware/errors.TestTakeStacktraceOptions")
+	})
+
+	t.Run("trim paths, expect relative file paths", func(t *testing.T) {
+		_, file, _, _ := runtime.Caller(0)
+		trace := TakeStacktraceOptions(0, StacktraceFirst, StackTrimPaths(filepath.Dir(file)))
+
+		assert.True(t, strings.HasPrefix(strings.Split(trace, "\n")[1], "\tstacktrace_test.go:"), trace)
+	})
+}
+
+func TestSetStackOptions(t *testing.T) {
+	t.Run("options are set, expect formatted stacks use the options", func(t *testing.T) {
+		SetStackOptions(StackMaxFrames(1))
+		defer SetStackOptions()
+
+		err := wrapDepth(0, nil, "failed", nil, StacktraceFull)
+		assert.Len(t, strings.Split(err.StackTrace(), "\n"), 2)
+	})
+
+	t.Run("options are reset, expect full stack", func(t *testing.T) {
+		err := wrapDepth(0, nil, "failed", nil, StacktraceFull)
+		assert.Greater(t, len(strings.Split(err.StackTrace(), "\n")), 2)
+	})
+}
+
+func TestStackSourceLines(t *testing.T) {
+	t.Run("source lines option, expect source around the frame line", func(t *testing.T) {
+		trace := TakeStacktraceOptions(0, StacktraceFirst, StackSourceLines(1))
+
+		lines := strings.Split(trace, "\n")
+		require.Len(t, lines, 5)
+		assert.Contains(t, lines[0], "github.com/mrsoftware/errors.TestStackSourceLines")
+		assert.True(t, strings.HasPrefix(lines[2], "\t\t "), lines[2])
+		assert.True(t, strings.HasPrefix(lines[3], "\t\t>"), lines[3])
+		assert.Contains(t, lines[3], "trace := TakeStacktraceOptions(0, StacktraceFirst, StackSourceLines(1))")
+		assert.True(t, strings.HasPrefix(lines[4], "\t\t "), lines[4])
+	})
+
+	t.Run("source file not found, expect no source lines", func(t *testing.T) {
+		assert.Nil(t, sourceLines("not-found.go"))
+	})
+}
+
+func TestStackAll(t *testing.T) {
+	t.Run("all goroutines, expect stack of other goroutines", func(t *testing.T) {
+		done := make(chan struct{})
+		defer close(done)
+
+		started := make(chan struct{})
+		go func() {
+			close(started)
+			<-done
+		}()
+		<-started
+
+		trace := StackAll("stacks").Str
+		assert.True(t, strings.HasPrefix(trace, "goroutine "), trace)
+		assert.Contains(t, trace, "github.com/mrsoftware/errors.TestStackAll.func1")
+		assert.Contains(t, trace, "github.com/mrsoftware/errors.TestStackAll(")
+	})
+
+	t.Run("size limit, expect truncated stacks", func(t *testing.T) {
+		trace := StackAllSize("stacks", 10).Str
+
+		assert.Equal(t, 10+len(TruncationMarker), len(trace))
+		assert.True(t, strings.HasSuffix(trace, TruncationMarker))
+	})
+}
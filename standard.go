@@ -3,7 +3,12 @@ package errors
 import stdErr "errors"
 
 // Is reports whether any error in err's tree matches target. (calling go standard errors.Is).
+// in strict mode, an Error used as target is considered a sentinel (see SetStrict).
 func Is(err, target error) bool {
+	if StrictEnabled() {
+		markSentinel(target)
+	}
+
 	return stdErr.Is(err, target)
 }
 
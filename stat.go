@@ -0,0 +1,231 @@
+package errors
+
+import (
+	stdErr "errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// StatLabelOverflow is the label value reported once a key has reached its
+// distinct values limit.
+const StatLabelOverflow = "other"
+
+// StatLabels derives metric labels from a whitelist of field keys.
+//
+// To keep the metric cardinality safe, every key only accepts up to maxValues
+// distinct values, any new value after that is reported as StatLabelOverflow.
+type StatLabels struct {
+	keys      []string
+	maxValues int
+	seen      map[string]map[string]struct{}
+	mx        sync.Mutex
+}
+
+// NewStatLabels create new StatLabels for passed keys.
+// maxValues <= 0 means no limit on distinct values, repeated keys are kept once at their last position.
+func NewStatLabels(maxValues int, keys ...string) *StatLabels {
+	seen := make(map[string]map[string]struct{}, len(keys))
+	unique := make([]string, len(keys))
+	next := len(keys)
+
+	for i := len(keys) - 1; i >= 0; i-- {
+		if _, ok := seen[keys[i]]; ok {
+			continue
+		}
+
+		seen[keys[i]] = make(map[string]struct{})
+		next--
+		unique[next] = keys[i]
+	}
+
+	return &StatLabels{keys: unique[next:], maxValues: maxValues, seen: seen}
+}
+
+// Keys return label keys in order.
+func (l *StatLabels) Keys() []string {
+	return l.keys
+}
+
+// Values return label values of err in the same order as Keys.
+// the value of a key that is not in the error chain is an empty string.
+func (l *StatLabels) Values(err error) []string {
+	values := make([]string, len(l.keys))
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	for i, key := range l.keys {
+		values[i] = l.value(key, FindFieldInChain(key, err))
+	}
+
+	return values
+}
+
+// Labels return label key/values of err.
+func (l *StatLabels) Labels(err error) map[string]string {
+	values := l.Values(err)
+
+	labels := make(map[string]string, len(l.keys))
+	for i, key := range l.keys {
+		labels[key] = values[i]
+	}
+
+	return labels
+}
+
+func (l *StatLabels) value(key string, field Field) string {
+	if IsNilField(field) {
+		return ""
+	}
+
+	value := fmt.Sprint(field.Value())
+
+	seen := l.seen[key]
+	if _, ok := seen[value]; ok {
+		return value
+	}
+
+	if l.maxValues > 0 && len(seen) >= l.maxValues {
+		return StatLabelOverflow
+	}
+
+	seen[value] = struct{}{}
+
+	return value
+}
+
+// Stater is notified of the errors, to collect error statistics, see SetDefaultStat.
+type Stater interface {
+	OnError(err error)
+}
+
+// StaterFunc is a function that implements Stater.
+type StaterFunc func(err error)
+
+// OnError call f.
+func (f StaterFunc) OnError(err error) {
+	f(err)
+}
+
+// statHolder let atomic.Value store nil Stater.
+type statHolder struct {
+	stater Stater
+}
+
+var defaultStat atomic.Value
+
+// keyNoStat is the key of NoStat field, it's never added to errors.
+const keyNoStat = "\x00no_stat"
+
+// SetDefaultStat set the Stater that is notified of the new errors, nil disable it.
+// an error is new if it's created by New, Errorf, ... or it wraps a cause that has no Error in its chain,
+// so each failure is counted once, no matter how many times it's wrapped. see NoStat.
+// the Stater is notified when the error is created, so the fields that are added later (e.g. by AddFields,
+// WithCode or WithSeverity) are not seen by it, pass them to the constructor to have them in the statistics.
+func SetDefaultStat(stater Stater) {
+	defaultStat.Store(statHolder{stater: stater})
+}
+
+// GetDefaultStat return the Stater that is notified of the new errors, nil if there is none.
+func GetDefaultStat() Stater {
+	holder, _ := defaultStat.Load().(statHolder)
+
+	return holder.stater
+}
+
+// NoStat is a field that disable notifying the default Stater of the error, it's not added to the error.
+//
+//	errors.New("expected error", errors.NoStat())
+func NoStat() Field {
+	return Field{Key: keyNoStat, Type: FieldTypeBool, Integer: 1}
+}
+
+// stat notify the default Stater of err if it's new.
+func stat(err *Error, noStat bool) {
+	stater := GetDefaultStat()
+	if stater == nil || noStat {
+		return
+	}
+
+	var custom *Error
+	if err.cause != nil && stdErr.As(err.cause, &custom) {
+		return
+	}
+
+	stater.OnError(err)
+}
+
+// removeNoStat remove the NoStat field, and report whether it was found.
+func removeNoStat(fields []Field) ([]Field, bool) {
+	if !hasField(fields, keyNoStat) {
+		return fields, false
+	}
+
+	result := make([]Field, 0, len(fields)-1)
+	for _, field := range fields {
+		if field.Key != keyNoStat {
+			result = append(result, field)
+		}
+	}
+
+	return result, true
+}
+
+// StatKey is the key of the counts of CountStater.
+type StatKey struct {
+	Code     Code
+	Template string
+
+	// Message is the message of the error, it's empty for template errors,
+	// so their counts are not split by the values in the message.
+	Message string
+}
+
+// CountStater is a Stater that counts errors by their code, template and message.
+type CountStater struct {
+	counts map[StatKey]uint64
+	mx     sync.Mutex
+}
+
+// NewCountStater create new CountStater.
+func NewCountStater() *CountStater {
+	return &CountStater{counts: make(map[StatKey]uint64)}
+}
+
+// OnError count the error, nil errors are ignored.
+func (s *CountStater) OnError(err error) {
+	if err == nil {
+		return
+	}
+
+	key := StatKey{Code: GetCode(err), Template: TemplateName(err)}
+	if key.Template == "" {
+		key.Message = err.Error()
+	}
+
+	s.mx.Lock()
+	s.counts[key]++
+	s.mx.Unlock()
+}
+
+// Snapshot return copy of the counts.
+func (s *CountStater) Snapshot() map[StatKey]uint64 {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	snapshot := make(map[StatKey]uint64, len(s.counts))
+	for key, count := range s.counts {
+		snapshot[key] = count
+	}
+
+	return snapshot
+}
+
+// Reset remove the counts.
+func (s *CountStater) Reset() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.counts = make(map[StatKey]uint64)
+}
@@ -0,0 +1,157 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"io/fs"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatLabels(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fields found in chain, expect to get them as labels", func(t *testing.T) {
+		labels := errors.NewStatLabels(10, "region", "tenant_tier")
+
+		cause := errors.New("cause", errors.String("region", "eu"))
+		err := errors.Wrap(cause, "wrapper", errors.String("tenant_tier", "gold"))
+
+		assert.Equal(t, []string{"region", "tenant_tier"}, labels.Keys())
+		assert.Equal(t, []string{"eu", "gold"}, labels.Values(err))
+		assert.Equal(t, map[string]string{"region": "eu", "tenant_tier": "gold"}, labels.Labels(err))
+	})
+
+	t.Run("field is missing, expect empty label value", func(t *testing.T) {
+		labels := errors.NewStatLabels(10, "region")
+
+		assert.Equal(t, []string{""}, labels.Values(errors.New("some error")))
+	})
+
+	t.Run("too many distinct values, expect overflow bucket", func(t *testing.T) {
+		labels := errors.NewStatLabels(2, "region")
+
+		assert.Equal(t, []string{"eu"}, labels.Values(errors.New("e", errors.String("region", "eu"))))
+		assert.Equal(t, []string{"us"}, labels.Values(errors.New("e", errors.String("region", "us"))))
+		assert.Equal(t, []string{errors.StatLabelOverflow}, labels.Values(errors.New("e", errors.String("region", "asia"))))
+		assert.Equal(t, []string{"eu"}, labels.Values(errors.New("e", errors.String("region", "eu"))))
+	})
+
+	t.Run("duplicate keys, expect each key once at its last position", func(t *testing.T) {
+		labels := errors.NewStatLabels(10, "region", "tenant_tier", "region")
+
+		err := errors.New("e", errors.String("region", "eu"), errors.String("tenant_tier", "gold"))
+
+		assert.Equal(t, []string{"tenant_tier", "region"}, labels.Keys())
+		assert.Equal(t, []string{"gold", "eu"}, labels.Values(err))
+		assert.Equal(t, map[string]string{"region": "eu", "tenant_tier": "gold"}, labels.Labels(err))
+	})
+}
+
+func TestCountStater(t *testing.T) {
+	t.Parallel()
+
+	t.Run("errors are counted, expect counts by code, template and message", func(t *testing.T) {
+		registry := errors.NewRegistry()
+		assert.NoError(t, registry.Register("user.not_found", errors.Template{Message: "user {id} not found", Code: errors.CodeNotFound}))
+
+		stater := errors.NewCountStater()
+		stater.OnError(registry.New("user.not_found", errors.String("id", "1")))
+		stater.OnError(registry.New("user.not_found", errors.String("id", "2")))
+		stater.OnError(errors.New("failure", errors.CodeField(errors.CodeInternal)))
+		stater.OnError(nil)
+
+		assert.Equal(t, map[errors.StatKey]uint64{
+			{Code: errors.CodeNotFound, Template: "user.not_found"}: 2,
+			{Code: errors.CodeInternal, Message: "failure"}:         1,
+		}, stater.Snapshot())
+	})
+
+	t.Run("reset, expect no count", func(t *testing.T) {
+		stater := errors.NewCountStater()
+		stater.OnError(errors.New("failure"))
+
+		stater.Reset()
+
+		assert.Empty(t, stater.Snapshot())
+	})
+}
+
+func TestSetDefaultStat(t *testing.T) {
+	stater := errors.NewCountStater()
+	errors.SetDefaultStat(stater)
+	defer errors.SetDefaultStat(nil)
+
+	t.Run("new errors, expect counted once no matter how many times wrapped", func(t *testing.T) {
+		defer stater.Reset()
+
+		err := errors.Wrap(errors.New("failure"), "wrapper")
+		_ = errors.Wrap(err, "another wrapper")
+
+		assert.Equal(t, map[errors.StatKey]uint64{{Code: errors.CodeUnknown, Message: "failure"}: 1}, stater.Snapshot())
+	})
+
+	t.Run("wrap error with no Error in chain, expect counted", func(t *testing.T) {
+		defer stater.Reset()
+
+		_ = errors.Wrap(stdErrors.New("failure"), "wrapper")
+
+		assert.Equal(t, map[errors.StatKey]uint64{{Code: errors.CodeUnknown, Message: "wrapper: failure"}: 1}, stater.Snapshot())
+	})
+
+	t.Run("NoStat field is set, expect not counted and the field not added", func(t *testing.T) {
+		defer stater.Reset()
+
+		err := errors.New("expected", errors.NoStat(), errors.String("id", "1"))
+
+		assert.Empty(t, stater.Snapshot())
+		assert.Len(t, errors.GetChainFields(err), 1)
+	})
+	t.Run("NoStat field is added later, expect the field not added", func(t *testing.T) {
+		defer stater.Reset()
+
+		err := errors.AddFields(errors.New("failure"), errors.NoStat())
+
+		assert.Empty(t, errors.GetChainFields(err))
+	})
+
+	t.Run("plain error is omitted or classified, expect counted", func(t *testing.T) {
+		defer stater.Reset()
+
+		_ = errors.OmitCause(stdErrors.New("omitted"))
+		_ = errors.FromFS(fs.ErrNotExist, "")
+
+		assert.Equal(t, map[errors.StatKey]uint64{
+			{Code: errors.CodeUnknown, Message: "omitted"}:               1,
+			{Code: errors.CodeNotFound, Message: fs.ErrNotExist.Error()}: 1,
+		}, stater.Snapshot())
+	})
+
+	t.Run("plain error is looked up or adopted, expect not counted", func(t *testing.T) {
+		defer stater.Reset()
+
+		plain := stdErrors.New("plain")
+		created := errors.Metrics().ErrorsCreated
+
+		_ = errors.GetError(plain)
+		_ = errors.GetError(plain)
+		_ = errors.Adopt(plain)
+
+		assert.Empty(t, stater.Snapshot())
+		assert.Equal(t, created, errors.Metrics().ErrorsCreated)
+	})
+
+	t.Run("chain is collapsed, expect counted once", func(t *testing.T) {
+		errors.SetMaxChainDepth(3)
+		defer errors.SetMaxChainDepth(0)
+		defer stater.Reset()
+
+		err := errors.Wrap(stdErrors.New("failure"), "first")
+		for i := 0; i < 5; i++ {
+			err = errors.Wrap(err, "wrapper")
+		}
+
+		assert.Len(t, stater.Snapshot(), 1)
+	})
+}
@@ -0,0 +1,135 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// DiagnosticKind is the kind of problem found in strict mode.
+type DiagnosticKind string
+
+const (
+	// DiagnosticMissingCode is reported when an error is created without a Code in its chain.
+	DiagnosticMissingCode DiagnosticKind = "missing_code"
+
+	// DiagnosticUnsupportedReflect is reported when a Reflect field holds a type with no sensible representation.
+	DiagnosticUnsupportedReflect DiagnosticKind = "unsupported_reflect"
+
+	// DiagnosticSentinelMutation is reported when AddFields is called on a shared sentinel error.
+	DiagnosticSentinelMutation DiagnosticKind = "sentinel_mutation"
+
+	// DiagnosticMissingField is reported when an error is created from a Template without its required fields.
+	DiagnosticMissingField DiagnosticKind = "missing_field"
+)
+
+// Diagnostic is a problem of error hygiene found in strict mode.
+type Diagnostic struct {
+	Kind    DiagnosticKind
+	Message string
+	Caller  string
+}
+
+// String version of Diagnostic.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s (%s)", d.Kind, d.Message, d.Caller)
+}
+
+var (
+	strict        int32
+	diagnosticsMx sync.Mutex
+	diagnostics   []Diagnostic
+)
+
+// SetStrict enable or disable strict mode, it's meant to be enabled in tests.
+// in strict mode, creating an error without a Code, using Reflect fields with func, chan or unsafe pointer values
+// calling AddFields on a sentinel (an Error that is used as target of Is)
+// and creating an error from a Template without its required fields are recorded as Diagnostics.
+func SetStrict(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+
+	atomic.StoreInt32(&strict, value)
+}
+
+// StrictEnabled report whether strict mode is enabled.
+func StrictEnabled() bool {
+	return atomic.LoadInt32(&strict) == 1
+}
+
+// Diagnostics return the recorded diagnostics of strict mode.
+func Diagnostics() []Diagnostic {
+	diagnosticsMx.Lock()
+	defer diagnosticsMx.Unlock()
+
+	list := make([]Diagnostic, len(diagnostics))
+	copy(list, diagnostics)
+
+	return list
+}
+
+// ResetDiagnostics remove all recorded diagnostics.
+func ResetDiagnostics() {
+	diagnosticsMx.Lock()
+	diagnostics = nil
+	diagnosticsMx.Unlock()
+}
+
+func addDiagnostic(skip int, kind DiagnosticKind, msg string) {
+	diagnostic := Diagnostic{Kind: kind, Message: msg, Caller: callerLocation(skip + 1)}
+
+	diagnosticsMx.Lock()
+	diagnostics = append(diagnostics, diagnostic)
+	diagnosticsMx.Unlock()
+}
+
+// checkNewError check a new error, skip=0 identifies the caller of checkNewError.
+func checkNewError(skip int, cause error, fields []Field) {
+	if !hasField(fields, KeyCode) && (cause == nil || GetCode(cause) == CodeUnknown) {
+		addDiagnostic(skip+1, DiagnosticMissingCode, "error is created without code")
+	}
+
+	checkFields(skip+1, fields)
+}
+
+// checkAddFields check AddFields call, skip=0 identifies the caller of checkAddFields.
+func checkAddFields(skip int, err *Error, fields []Field) {
+	if atomic.LoadInt32(&err.sentinel) == 1 {
+		addDiagnostic(skip+1, DiagnosticSentinelMutation, fmt.Sprintf("AddFields is called on sentinel %q", err.msg))
+	}
+
+	checkFields(skip+1, fields)
+}
+
+func checkFields(skip int, fields []Field) {
+	for _, field := range fields {
+		if field.Type != FieldTypeReflect || field.Interface == nil {
+			continue
+		}
+
+		switch reflect.TypeOf(field.Interface).Kind() { // nolint: exhaustive
+		case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+			addDiagnostic(skip+1, DiagnosticUnsupportedReflect, fmt.Sprintf("field %q holds %T", field.Key, field.Interface))
+		}
+	}
+}
+
+func markSentinel(target error) {
+	if custom, ok := target.(*Error); ok { // nolint: errorlint
+		atomic.StoreInt32(&custom.sentinel, 1)
+	}
+}
+
+// callerLocation return file:line of the caller, skip=0 identifies the caller of callerLocation.
+func callerLocation(skip int) string {
+	_, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return "unknown"
+	}
+
+	return fmt.Sprintf("%s:%d", file, line)
+}
@@ -0,0 +1,58 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetStrict(t *testing.T) {
+	errors.SetStrict(true)
+	defer errors.SetStrict(false)
+	defer errors.ResetDiagnostics()
+
+	t.Run("error has code, expect no diagnostic", func(t *testing.T) {
+		errors.ResetDiagnostics()
+
+		cause := errors.New("cause", errors.CodeField(errors.CodeNotFound))
+		_ = errors.Wrap(cause, "some error", errors.Reflect("user", struct{ Name string }{}))
+
+		assert.Empty(t, errors.Diagnostics())
+	})
+
+	t.Run("error has no code, expect missing code diagnostic", func(t *testing.T) {
+		errors.ResetDiagnostics()
+
+		_ = errors.New("some error")
+
+		diagnostics := errors.Diagnostics()
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, errors.DiagnosticMissingCode, diagnostics[0].Kind)
+		assert.Contains(t, diagnostics[0].Caller, "strict_test.go")
+	})
+
+	t.Run("reflect field holds a func, expect unsupported reflect diagnostic", func(t *testing.T) {
+		errors.ResetDiagnostics()
+
+		_ = errors.New("some error", errors.CodeField(errors.CodeInternal), errors.Reflect("callback", func() {}))
+
+		diagnostics := errors.Diagnostics()
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, errors.DiagnosticUnsupportedReflect, diagnostics[0].Kind)
+	})
+
+	t.Run("AddFields is called on a sentinel, expect sentinel mutation diagnostic", func(t *testing.T) {
+		sentinel := errors.New("sentinel", errors.CodeField(errors.CodeNotFound))
+		_ = errors.Is(errors.Wrap(sentinel, "wrapper"), sentinel)
+
+		errors.ResetDiagnostics()
+
+		_ = errors.AddFields(sentinel, errors.String("id", "10"))
+
+		diagnostics := errors.Diagnostics()
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, errors.DiagnosticSentinelMutation, diagnostics[0].Kind)
+	})
+}
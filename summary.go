@@ -0,0 +1,87 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SummaryGroup is a group of errors with the same fingerprint.
+type SummaryGroup struct {
+	// Fingerprint of errors in group, it's the error message.
+	Fingerprint string
+
+	// Code of errors in group.
+	Code Code
+
+	// Count of errors in group.
+	Count int
+
+	// Example is the first error of the group.
+	Example error
+}
+
+// Summary is the summary of a MultiError, see MultiError.Summarize.
+type Summary struct {
+	// Total number of errors, including the dropped ones.
+	Total int
+
+	// Dropped is the number of errors that are dropped by the limit of the MultiError (see MultiErrorWithLimit),
+	// they are counted in Total, but not in Groups.
+	Dropped int
+
+	// Groups of errors sorted by count, most common first.
+	Groups []SummaryGroup
+}
+
+// String version of Summary, like "3 distinct errors across 120 failures (most common: connection refused x97)".
+func (s Summary) String() string {
+	if s.Total == 0 {
+		return "no failures"
+	}
+
+	return fmt.Sprintf(
+		"%d distinct %s across %d %s (most common: %s x%d)",
+		len(s.Groups), plural(len(s.Groups), "error", "errors"),
+		s.Total, plural(s.Total, "failure", "failures"),
+		s.Groups[0].Fingerprint, s.Groups[0].Count,
+	)
+}
+
+// Summarize group the errors by code and message, with count and one example of each group.
+func (m *MultiError) Summarize() Summary {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	type groupKey struct {
+		code        Code
+		fingerprint string
+	}
+
+	indexes := make(map[groupKey]int)
+	summary := Summary{Total: len(m.errors) + m.dropped, Dropped: m.dropped}
+
+	for _, err := range m.errors {
+		key := groupKey{code: GetCode(err), fingerprint: err.Error()}
+
+		index, ok := indexes[key]
+		if !ok {
+			index = len(summary.Groups)
+			indexes[key] = index
+			summary.Groups = append(summary.Groups, SummaryGroup{Fingerprint: key.fingerprint, Code: key.code, Example: err})
+		}
+
+		summary.Groups[index].Count++
+	}
+
+	sort.SliceStable(summary.Groups, func(i, j int) bool { return summary.Groups[i].Count > summary.Groups[j].Count })
+
+	return summary
+}
+
+func plural(count int, singular, plural string) string {
+	if count == 1 {
+		return singular
+	}
+
+	return plural
+}
@@ -0,0 +1,65 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiError_Summarize(t *testing.T) {
+	t.Run("no error, expect empty summary", func(t *testing.T) {
+		summary := NewMultiError().Summarize()
+
+		assert.Equal(t, 0, summary.Total)
+		assert.Equal(t, "no failures", summary.String())
+	})
+
+	t.Run("some errors, expect groups sorted by count", func(t *testing.T) {
+		refused := errors.New("connection refused")
+		multi := NewMultiError()
+
+		for i := 0; i < 97; i++ {
+			multi.Add(errors.New("connection refused"))
+		}
+
+		for i := 0; i < 20; i++ {
+			multi.Add(New("not found", CodeField(CodeNotFound)))
+		}
+
+		for i := 0; i < 3; i++ {
+			multi.Add(errors.New("timeout"))
+		}
+
+		multi.errors[0] = refused
+
+		summary := multi.Summarize()
+
+		require.Len(t, summary.Groups, 3)
+		assert.Equal(t, 120, summary.Total)
+		assert.Equal(t, SummaryGroup{Fingerprint: "connection refused", Code: CodeUnknown, Count: 97, Example: refused}, summary.Groups[0])
+		assert.Equal(t, CodeNotFound, summary.Groups[1].Code)
+		assert.Equal(t, 20, summary.Groups[1].Count)
+		assert.Equal(t, "3 distinct errors across 120 failures (most common: connection refused x97)", summary.String())
+	})
+
+	t.Run("one error, expect singular summary", func(t *testing.T) {
+		summary := NewMultiError(errors.New("timeout")).Summarize()
+
+		assert.Equal(t, "1 distinct error across 1 failure (most common: timeout x1)", summary.String())
+	})
+
+	t.Run("errors are dropped by the limit, expect dropped errors in total", func(t *testing.T) {
+		multi := NewMultiErrorWithOptions(MultiErrorWithLimit(2))
+		for i := 0; i < 10; i++ {
+			multi.Add(errors.New("timeout"))
+		}
+
+		summary := multi.Summarize()
+
+		assert.Equal(t, 10, summary.Total)
+		assert.Equal(t, 8, summary.Dropped)
+		assert.Equal(t, "1 distinct error across 10 failures (most common: timeout x2)", summary.String())
+	})
+}
@@ -0,0 +1,55 @@
+package errors
+
+// TagsField constructs a field that carries tags.
+func TagsField(tags ...string) Field {
+	copied := make([]string, len(tags))
+	copy(copied, tags)
+
+	return Reflect(KeyTags, copied)
+}
+
+// WithTags add the tags to the passed error (see AddFields), tags are a coarse classification orthogonal to codes and fields.
+//
+//	return errors.WithTags(err, "db", "timeout")
+func WithTags(err error, tags ...string) error {
+	if err == nil {
+		return nil
+	}
+
+	return AddFields(err, TagsField(tags...))
+}
+
+// TagsOf return the tags of the error chain, outermost first and without duplicates.
+func TagsOf(err error) []string {
+	tags := make([]string, 0)
+	seen := make(map[string]struct{})
+
+	for _, field := range GetChainFields(err) {
+		if field.Key != KeyTags {
+			continue
+		}
+
+		values, _ := field.Interface.([]string)
+		for _, tag := range values {
+			if _, ok := seen[tag]; ok {
+				continue
+			}
+
+			seen[tag] = struct{}{}
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// HasTag report whether the tag is in the error chain.
+func HasTag(err error, tag string) bool {
+	for _, item := range TagsOf(err) {
+		if item == tag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,36 @@
+package errors_test
+
+import (
+	stdErrors "errors"
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagsOf(t *testing.T) {
+	t.Parallel()
+
+	t.Run("tags in chain, expect all tags without duplicates", func(t *testing.T) {
+		cause := errors.WithTags(stdErrors.New("connection reset"), "db", "network")
+		err := errors.WithTags(errors.Wrap(cause, "query user"), "timeout", "db")
+
+		assert.Equal(t, []string{"timeout", "db", "network"}, errors.TagsOf(err))
+		assert.True(t, errors.HasTag(err, "network"))
+		assert.False(t, errors.HasTag(err, "cache"))
+	})
+
+	t.Run("no tags, expect empty", func(t *testing.T) {
+		assert.Empty(t, errors.TagsOf(errors.New("some error")))
+		assert.Empty(t, errors.TagsOf(nil))
+		assert.Nil(t, errors.WithTags(nil, "db"))
+	})
+
+	t.Run("passed tags are changed, expect tags of error to not change", func(t *testing.T) {
+		tags := []string{"db"}
+		err := errors.WithTags(errors.New("some error"), tags...)
+		tags[0] = "cache"
+
+		assert.Equal(t, []string{"db"}, errors.TagsOf(err))
+	})
+}
@@ -0,0 +1,124 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Template is a named error definition, errors are instantiated from it by FromTemplate.
+type Template struct {
+	Code           Code
+	Message        string
+	RequiredFields []string
+}
+
+// Registry is a set of named templates, it centralizes error definitions of large services.
+type Registry struct {
+	mx        sync.RWMutex
+	templates map[string]Template
+}
+
+// NewRegistry create a new Registry.
+func NewRegistry() *Registry {
+	return &Registry{templates: make(map[string]Template)}
+}
+
+// Register add the template with the name, registering an existing name return error.
+func (r *Registry) Register(name string, template Template) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if _, ok := r.templates[name]; ok {
+		return New("error template is already registered", String(KeyTemplate, name), CodeField(CodeAlreadyExists))
+	}
+
+	r.templates[name] = template
+
+	return nil
+}
+
+// Template return the template of the name.
+func (r *Registry) Template(name string) (Template, bool) {
+	r.mx.RLock()
+	defer r.mx.RUnlock()
+
+	template, ok := r.templates[name]
+
+	return template, ok
+}
+
+// Names return the names of all templates sorted.
+func (r *Registry) Names() []string {
+	r.mx.RLock()
+	defer r.mx.RUnlock()
+
+	names := make([]string, 0, len(r.templates))
+	for name := range r.templates {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// New create an error from the template of the name, see FromTemplate.
+func (r *Registry) New(name string, fields ...Field) error {
+	return r.newError(1, name, fields) // skip New
+}
+
+// newError create an error from the template, skip=0 identifies the caller of newError.
+func (r *Registry) newError(skip int, name string, fields []Field) error {
+	template, ok := r.Template(name)
+	if !ok {
+		return wrap(skip+1, nil, "unknown error template", []Field{String("name", name), CodeField(CodeInternal)})
+	}
+
+	if StrictEnabled() {
+		for _, key := range template.RequiredFields {
+			if !hasField(fields, key) {
+				addDiagnostic(skip+1, DiagnosticMissingField, fmt.Sprintf("error template %q requires field %q", name, key))
+			}
+		}
+	}
+
+	templateFields := make([]Field, 0, len(fields)+2) // nolint: gomnd
+	templateFields = append(templateFields, String(KeyTemplate, name), CodeField(template.Code))
+
+	return wrap(skip+1, nil, template.Message, append(templateFields, fields...))
+}
+
+var defaultRegistry = NewRegistry()
+
+// Register add the template with the name to the default registry.
+//
+//	errors.Register("user.not_found", errors.Template{Code: errors.CodeNotFound, Message: "user not found", RequiredFields: []string{"id"}})
+func Register(name string, template Template) error {
+	return defaultRegistry.Register(name, template)
+}
+
+// FromTemplate create an error from the template of the name in the default registry,
+// the name and the code of the template are added as fields before the passed fields.
+// if the template is not registered, an error with CodeInternal is returned.
+func FromTemplate(name string, fields ...Field) error {
+	return defaultRegistry.newError(1, name, fields) // skip FromTemplate
+}
+
+// TemplateName return the name of the template of the outermost error in chain that is created from a template.
+func TemplateName(err error) string {
+	name, _ := FindFieldInChain(KeyTemplate, err).StringValue()
+
+	return name
+}
+
+// IsTemplate report whether an error in chain is created from the template of the name.
+func IsTemplate(err error, name string) bool {
+	for _, field := range GetChainFields(err) {
+		if field.Key == KeyTemplate && field.Str == name {
+			return true
+		}
+	}
+
+	return false
+}
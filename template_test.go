@@ -0,0 +1,74 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromTemplate(t *testing.T) {
+	t.Cleanup(func() { errors.UnregisterTemplate("test.user_not_found") })
+
+	require.NoError(t, errors.Register("test.user_not_found", errors.Template{
+		Code:           errors.CodeNotFound,
+		Message:        "user not found",
+		RequiredFields: []string{"id"},
+	}))
+
+	t.Run("template is registered, expect error with code and fields", func(t *testing.T) {
+		err := errors.Wrap(errors.FromTemplate("test.user_not_found", errors.String("id", "1")), "get profile")
+
+		assert.Equal(t, "get profile: user not found", err.Error())
+		assert.Equal(t, errors.CodeNotFound, errors.GetCode(err))
+		assert.Equal(t, "1", errors.FindFieldInChain("id", err).Str)
+		assert.Equal(t, "test.user_not_found", errors.TemplateName(err))
+		assert.True(t, errors.IsTemplate(err, "test.user_not_found"))
+		assert.False(t, errors.IsTemplate(err, "test.other"))
+	})
+
+	t.Run("template is registered twice, expect error", func(t *testing.T) {
+		err := errors.Register("test.user_not_found", errors.Template{Message: "other"})
+
+		assert.Equal(t, errors.CodeAlreadyExists, errors.GetCode(err))
+	})
+
+	t.Run("template is not registered, expect internal error", func(t *testing.T) {
+		err := errors.FromTemplate("test.not_registered")
+
+		assert.Equal(t, "unknown error template", err.Error())
+		assert.Equal(t, errors.CodeInternal, errors.GetCode(err))
+		assert.Empty(t, errors.TemplateName(errors.New("some error")))
+	})
+
+	t.Run("required field is missing in strict mode, expect diagnostic", func(t *testing.T) {
+		errors.SetStrict(true)
+		defer errors.SetStrict(false)
+		defer errors.ResetDiagnostics()
+
+		errors.ResetDiagnostics()
+		_ = errors.FromTemplate("test.user_not_found")
+
+		diagnostics := errors.Diagnostics()
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, errors.DiagnosticMissingField, diagnostics[0].Kind)
+		assert.Contains(t, diagnostics[0].Caller, "template_test.go")
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	registry := errors.NewRegistry()
+	require.NoError(t, registry.Register("b", errors.Template{Code: errors.CodeInvalidArgument, Message: "b"}))
+	require.NoError(t, registry.Register("a", errors.Template{Code: errors.CodeTimeout, Message: "a"}))
+
+	template, ok := registry.Template("a")
+
+	assert.True(t, ok)
+	assert.Equal(t, "a", template.Message)
+	assert.Equal(t, []string{"a", "b"}, registry.Names())
+	assert.Equal(t, errors.CodeInvalidArgument, errors.GetCode(registry.New("b")))
+	assert.False(t, errors.IsTemplate(errors.FromTemplate("b"), "b"))
+}
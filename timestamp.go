@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+var timestamps int32
+
+// SetTimestamps control whether New, Wrap and other constructors record the creation time (see CreatedAt),
+// it's disabled by default. the time is taken from the clock (see SetClock).
+func SetTimestamps(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+
+	atomic.StoreInt32(&timestamps, value)
+}
+
+// TimestampsEnabled report whether the creation time of errors is recorded.
+func TimestampsEnabled() bool {
+	return atomic.LoadInt32(&timestamps) == 1
+}
+
+// CreatedAt return the creation time of the outermost Error in chain that has it, false if no time is recorded.
+func CreatedAt(err error) (time.Time, bool) {
+	var custom *Error
+	for As(err, &custom) {
+		if !custom.createdAt.IsZero() {
+			return custom.createdAt, true
+		}
+
+		err = custom.cause
+	}
+
+	return time.Time{}, false
+}
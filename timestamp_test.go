@@ -0,0 +1,53 @@
+package errors_test
+
+import (
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mrsoftware/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatedAt(t *testing.T) {
+	t.Run("timestamps are disabled, expect no time", func(t *testing.T) {
+		_, ok := errors.CreatedAt(errors.New("some error"))
+
+		assert.False(t, ok)
+		assert.Equal(t, "some error", fmt.Sprintf("%+v", errors.New("some error")))
+	})
+
+	t.Run("timestamps are enabled, expect the creation time of the outermost error", func(t *testing.T) {
+		createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		errors.SetTimestamps(true)
+		errors.SetClock(func() time.Time { return createdAt })
+
+		defer errors.SetTimestamps(false)
+		defer errors.SetClock(nil)
+
+		err := fmt.Errorf("wrapper: %w", errors.Wrap(stdErrors.New("cause"), "some error"))
+
+		value, ok := errors.CreatedAt(err)
+		assert.True(t, errors.TimestampsEnabled())
+		assert.True(t, ok)
+		assert.Equal(t, createdAt, value)
+		assert.Equal(t, "some error: cause\ncreated at: 2024-01-02T03:04:05Z", fmt.Sprintf("%+v", errors.GetError(err)))
+
+		recorder := httptest.NewRecorder()
+		require.NoError(t, errors.WriteHTTPError(recorder, err))
+
+		var body errors.HTTPErrorBody
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		require.NotNil(t, body.CreatedAt)
+		assert.True(t, createdAt.Equal(*body.CreatedAt))
+
+		enc := errors.NewJSONEncoder()
+		require.NoError(t, errors.EncodeError(err, enc))
+		assert.Contains(t, enc.String(), `"created_at":"2024-01-02T03:04:05Z"`)
+	})
+}
@@ -1,46 +1,994 @@
 package errors
 
 import (
+	"context"
+	stdErr "errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
+// ErrDraining is recorded for the tasks that are added while the WaitGroup is draining, see WaitGroup.Drain.
+var ErrDraining = New("wait group is draining")
+
 // WaitGroup is sync.WaitGroup with error support.
 type WaitGroup struct {
 	noCopy noCopy
 	wg     sync.WaitGroup
 	errors MultiError
+
+	errorStacks     bool
+	errorStackDepth StacktraceDepth
+	panicRecovery   bool
+	taskTimeout     time.Duration
+	pool            *workerPool
+	draining        int32
+	runningMx       sync.Mutex
+	running         map[int]string // name of the running tasks by index, see Drain.
+	limiter         taskLimiter
+	tasks           int64 // number of started tasks, used as the index of tasks.
+	firstError      bool
+	signals         []os.Signal
+	signalsStop     chan struct{} // closed by Wait to stop watching the signals.
+	signalsDone     chan struct{} // closed when the signals are not watched anymore.
+	signalsOnce     sync.Once
+	hooks           WaitGroupHooks
+	taskWrapper     TaskWrapper
+	rateLimiter     RateLimiter
+	errorMapper     func(err error) error
+	ignored         []error
+	ctx             context.Context
+	cancel          context.CancelCauseFunc
+	streamMx        sync.Mutex
+	stream          *errorStream
+}
+
+// WaitGroupOption configure the WaitGroup, see NewWaitGroup.
+type WaitGroupOption func(g *WaitGroup)
+
+// WaitGroupWithErrorStacks wrap each error that has no stack with the stack of the caller of Done (see WithStack),
+// the errors of tasks that run by Do get the stack of the caller of Do, so aggregated errors show where they came from.
+// depth control the cost, StacktraceFirst captures only the caller frame.
+func WaitGroupWithErrorStacks(depth StacktraceDepth) WaitGroupOption {
+	return func(g *WaitGroup) {
+		g.errorStacks = true
+		g.errorStackDepth = depth
+	}
+}
+
+// WaitGroupWithPanicRecovery recover panics of tasks that run by Do, the panic is recorded as an error
+// with the panic value (KeyPanic) and the stack of the panic (KeyStack) as fields, see IsPanic.
+func WaitGroupWithPanicRecovery() WaitGroupOption {
+	return func(g *WaitGroup) {
+		g.panicRecovery = true
+	}
+}
+
+// WaitGroupWithTaskLimit limit the number of tasks that run by Do at the same time, see SetLimit.
+func WaitGroupWithTaskLimit(n int) WaitGroupOption {
+	return func(g *WaitGroup) {
+		g.SetLimit(n)
+	}
+}
+
+// WaitGroupWithTaskTimeout set the timeout of the context of tasks, if a task fails after the timeout,
+// its error is wrapped as "task timeout" with the timeout (KeyTimeout) as field, see DoTimeout.
+func WaitGroupWithTaskTimeout(timeout time.Duration) WaitGroupOption {
+	return func(g *WaitGroup) {
+		g.taskTimeout = timeout
+	}
+}
+
+// WaitGroupWithWorkers run the tasks by n workers that consume a queue of queueSize tasks, instead of
+// a goroutine per task. Do blocks while the queue is full. the workers exit when there is no pending task.
+func WaitGroupWithWorkers(n int, queueSize int) WaitGroupOption {
+	return func(g *WaitGroup) {
+		if n < 1 {
+			n = 1
+		}
+
+		g.pool = &workerPool{workers: n, queueSize: queueSize}
+	}
+}
+
+// WaitGroupWithContext set the parent of the group context, see WaitGroup.Context.
+func WaitGroupWithContext(ctx context.Context) WaitGroupOption {
+	return func(g *WaitGroup) {
+		g.ctx, g.cancel = context.WithCancelCause(ctx)
+	}
+}
+
+// WaitGroupWithFirstError make Wait return only the first error instead of MultiError, and the first error
+// cancels the group context, like errgroup.Group of golang.org/x/sync, so it can be used as a drop-in replacement.
+func WaitGroupWithFirstError() WaitGroupOption {
+	return func(g *WaitGroup) {
+		g.firstError = true
+	}
+}
+
+// WaitGroupWithSignals cancel the group context when one of the signals arrives, and record ErrSignal as an error.
+// the signals are watched until the group context is done or Wait returns, after that the signals
+// have their default behavior again.
+//
+//	wg := errors.NewWaitGroup(errors.WaitGroupWithSignals(os.Interrupt, syscall.SIGTERM))
+func WaitGroupWithSignals(signals ...os.Signal) WaitGroupOption {
+	return func(g *WaitGroup) {
+		g.signals = append(g.signals, signals...)
+	}
+}
+
+// WaitGroupHooks are called by the WaitGroup for the tasks of Do methods, so metrics like the number of tasks,
+// failure rate and limit wait time can be exported. nil hooks are not called, hooks must be safe for concurrent use.
+type WaitGroupHooks struct {
+	// OnTaskStart is called before the task runs.
+	OnTaskStart func(name string, index int)
+
+	// OnTaskDone is called after the task is done, err is the error of the task or nil.
+	OnTaskDone func(name string, index int, err error, duration time.Duration)
+
+	// OnLimitWait is called after waiting for the limit of SetLimit, with the wait duration.
+	OnLimitWait func(duration time.Duration)
+}
+
+// WaitGroupWithHooks set the hooks of the group, see WaitGroupHooks.
+func WaitGroupWithHooks(hooks WaitGroupHooks) WaitGroupOption {
+	return func(g *WaitGroup) {
+		g.hooks = hooks
+	}
+}
+
+// TaskWrapper wrap the run of the tasks of Do methods, next must be called with the task context or
+// a context derived from it. it can be used to start a tracing span per task, see otelerrors package.
+type TaskWrapper func(ctx context.Context, name string, index int, next func(ctx context.Context) error) error
+
+// WaitGroupWithTaskWrapper set the TaskWrapper of the group.
+func WaitGroupWithTaskWrapper(wrapper TaskWrapper) WaitGroupOption {
+	return func(g *WaitGroup) {
+		g.taskWrapper = wrapper
+	}
+}
+
+// RateLimiter throttle the start of tasks, Wait blocks until a task can start or ctx is done.
+// *rate.Limiter of golang.org/x/time/rate implements it.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// WaitGroupWithRateLimiter throttle the start of tasks of Do methods by the limiter, the Do methods block until
+// the task can start. if the task context is done before, the task is not run and the error of Wait is recorded.
+//
+//	wg := errors.NewWaitGroup(errors.WaitGroupWithRateLimiter(rate.NewLimiter(10, 1)))
+func WaitGroupWithRateLimiter(limiter RateLimiter) WaitGroupOption {
+	return func(g *WaitGroup) {
+		g.rateLimiter = limiter
+	}
+}
+
+// WaitGroupWithErrorMapper apply mapper to every error passed to Done before it's recorded, so the policies like
+// adding fields, classifying or dropping errors are in one place. if mapper returns nil, the error is dropped.
+//
+//	errors.WaitGroupWithErrorMapper(func(err error) error { return errors.Wrap(err, "sync", errors.String("job", "sync")) })
+func WaitGroupWithErrorMapper(mapper func(err error) error) WaitGroupOption {
+	return func(g *WaitGroup) {
+		g.errorMapper = mapper
+	}
+}
+
+// WaitGroupIgnore drop the errors passed to Done that match one of the errors by errors.Is, like context.Canceled
+// of the tasks that are canceled by the first error. it's applied after the mapper of WaitGroupWithErrorMapper.
+//
+//	errors.WaitGroupIgnore(context.Canceled, sql.ErrNoRows)
+func WaitGroupIgnore(errs ...error) WaitGroupOption {
+	return func(g *WaitGroup) {
+		g.ignored = append(g.ignored, errs...)
+	}
+}
+
+// ErrSignal is recorded by the WaitGroup when a signal of WaitGroupWithSignals arrives.
+type ErrSignal struct {
+	Signal os.Signal
+}
+
+// Error implements the error interface.
+func (e ErrSignal) Error() string {
+	return "signal: " + e.Signal.String()
 }
 
 // NewWaitGroup create new WaitGroup.
-func NewWaitGroup() *WaitGroup {
-	return &WaitGroup{}
+func NewWaitGroup(options ...WaitGroupOption) *WaitGroup {
+	group := &WaitGroup{}
+	group.ctx, group.cancel = context.WithCancelCause(context.Background())
+
+	for _, option := range options {
+		option(group)
+	}
+
+	if len(group.signals) > 0 {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, group.signals...)
+
+		group.signalsStop = make(chan struct{})
+		group.signalsDone = make(chan struct{})
+
+		go func() {
+			defer close(group.signalsDone)
+			defer signal.Stop(signals)
+
+			group.watchSignals(signals, group.signalsStop)
+		}()
+	}
+
+	return group
+}
+
+// watchSignals record the first signal and cancel the group context with it, until stop is closed.
+func (g *WaitGroup) watchSignals(signals <-chan os.Signal, stop <-chan struct{}) {
+	select {
+	case sig := <-signals:
+		err := ErrSignal{Signal: sig}
+
+		g.record(err, nil)
+		g.cancel(err)
+	case <-g.ctx.Done():
+	case <-stop:
+	}
+}
+
+// stopSignals stop watching the signals and wait for the watcher, so no signal is recorded after it.
+func (g *WaitGroup) stopSignals() {
+	if g.signalsStop == nil {
+		return
+	}
+
+	g.signalsOnce.Do(func() { close(g.signalsStop) })
+	<-g.signalsDone
 }
 
 // Wait is sync.WaitGroup.Wait.
 func (g *WaitGroup) Wait() error {
 	g.wg.Wait()
+	g.stopSignals()
+	g.closeStream()
+
+	errs := g.errors.Errors()
+
+	// the group context is canceled like errgroup.Group.Wait, so it's not leaked and the waiters of it return.
+	if g.cancel != nil {
+		var cause error
+		if len(errs) > 0 {
+			cause = errs[0]
+		}
 
-	if g.errors.Len() == 0 {
+		g.cancel(cause)
+	}
+
+	if len(errs) == 0 {
 		return nil
 	}
 
+	if g.firstError {
+		return errs[0]
+	}
+
 	return &g.errors
 }
 
+// Context return the context of the group, it's canceled by Stop, by the first error in first error mode
+// (see WaitGroupWithFirstError) and when Wait returns, with the first error as cause.
+// the context of a WaitGroup that is not created by NewWaitGroup is never canceled.
+func (g *WaitGroup) Context() context.Context {
+	if g.ctx == nil {
+		return context.Background()
+	}
+
+	return g.ctx
+}
+
+// Stop cancel the context of the group, running tasks are not waited.
+func (g *WaitGroup) Stop() {
+	if g.cancel != nil {
+		g.cancel(nil)
+	}
+}
+
+// WaitContext is like Wait, but returns ctx.Err() if ctx is done before the tasks are done.
+// the tasks are not stopped, so Wait can be called again to wait for them.
+func (g *WaitGroup) WaitContext(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return g.Wait()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WaitTimeout is like Wait, but returns a timeout error (context.DeadlineExceeded) if the tasks are not done in timeout.
+func (g *WaitGroup) WaitTimeout(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// WaitContext returns the exact ctx.Err() only if the tasks are not done in time.
+	if err := g.WaitContext(ctx); err == nil || err != ctx.Err() { // nolint: errorlint
+		return err
+	}
+
+	return wrap(1, context.DeadlineExceeded, "wait timeout", []Field{Duration(KeyTimeout, timeout)}) // skip WaitTimeout
+}
+
 // Add is sync.WaitGroup.Add.
 func (g *WaitGroup) Add(delta int) {
 	g.wg.Add(delta)
 }
 
+// isIgnored report whether err match one of the errors of WaitGroupIgnore.
+func (g *WaitGroup) isIgnored(err error) bool {
+	for _, ignored := range g.ignored {
+		if stdErr.Is(err, ignored) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Done is sync.WaitGroup.Done, but is support error as parameter.
 func (g *WaitGroup) Done(err error) {
-	g.wg.Done()
+	var stack []uintptr
+	if err != nil && g.errorStacks {
+		stack = captureStack(1, g.errorStackDepth) // skip Done
+	}
+
+	g.done(err, stack)
+}
+
+// done record the error with the stack of WaitGroupWithErrorStacks and call Done of the sync.WaitGroup.
+func (g *WaitGroup) done(err error, stack []uintptr) {
+	// the error is recorded before calling Done, so Wait always return it.
+	defer g.wg.Done()
+
+	g.record(err, stack)
+}
+
+// record add the error to the errors of the group, after applying the mapper and the ignored errors.
+// if the error has no stack, it's wrapped with stack.
+func (g *WaitGroup) record(err error, stack []uintptr) {
+	if err != nil && g.errorMapper != nil {
+		err = g.errorMapper(err)
+	}
+
+	if err == nil || g.isIgnored(err) {
+		return
+	}
+
+	if len(stack) != 0 && !hasStack(err) {
+		wrapped := wrapDepth(0, err, "", nil, StacktraceNone)
+		wrapped.stack = stack
+		err = wrapped
+	}
+
+	g.errors.mx.Lock()
+	first := len(g.errors.errors) == 0
+	g.errors.add(err)
+	g.errors.mx.Unlock()
+
+	if first && g.firstError && g.cancel != nil {
+		g.cancel(err)
+	}
+
+	g.streamMx.Lock()
+	if g.stream != nil {
+		g.stream.push(err)
+	}
+	g.streamMx.Unlock()
+}
+
+// Errors return a channel that receives the errors of tasks as they occur, while other tasks are still running.
+// only the errors that occur after the call are received, and the channel is closed when Wait returns.
+// the channel is buffered without limit, so a slow receiver never blocks the tasks.
+func (g *WaitGroup) Errors() <-chan error {
+	g.streamMx.Lock()
+	defer g.streamMx.Unlock()
+
+	if g.stream == nil {
+		g.stream = newErrorStream()
+	}
+
+	return g.stream.ch
+}
+
+// closeStream close the stream of Errors, the next call of Errors creates a new stream.
+func (g *WaitGroup) closeStream() {
+	g.streamMx.Lock()
+	defer g.streamMx.Unlock()
+
+	if g.stream != nil {
+		g.stream.close()
+		g.stream = nil
+	}
+}
+
+// SetLimit set the number of tasks that run by Do at the same time, n < 1 means no limit.
+// it can be called while tasks are running, if the limit shrinks, running tasks are not stopped,
+// but new tasks wait until the number of running tasks is less than the new limit.
+func (g *WaitGroup) SetLimit(n int) {
+	g.limiter.setLimit(n)
+}
+
+// Do run f with the group context in a new goroutine, see DoCtx and Context.
+func (g *WaitGroup) Do(f func(ctx context.Context) error) {
+	g.DoCtx(g.Context(), f)
+}
+
+// DoCtx run f with ctx in a new goroutine, the error of f is recorded like Done.
+// if there is a task limit (see SetLimit), DoCtx blocks until a task is done.
+func (g *WaitGroup) DoCtx(ctx context.Context, f func(ctx context.Context) error) {
+	g.acquire()
+	g.start(ctx, task{f: f, timeout: g.taskTimeout})
+}
+
+// DoNamed is like DoCtx, but the error of f is wrapped with the name (KeyTask) and the index (KeyTaskIndex)
+// of the task as fields, so the errors of the aggregated MultiError can be attributed to tasks, see TaskOf.
+func (g *WaitGroup) DoNamed(ctx context.Context, name string, f func(ctx context.Context) error) {
+	g.acquire()
+	g.start(ctx, task{name: name, f: f, timeout: g.taskTimeout})
+}
+
+// DoIndexed is like DoCtx, but the error of f is wrapped with index as field (KeyIndex), so the errors of
+// a fan-out over a slice can be reported per item, see IndexOf and ForEach.
+func (g *WaitGroup) DoIndexed(ctx context.Context, index int, f func(ctx context.Context) error) {
+	g.DoCtx(ctx, func(ctx context.Context) error {
+		if err := f(ctx); err != nil {
+			return wrap(0, err, "", []Field{Int(KeyIndex, index)})
+		}
+
+		return nil
+	})
+}
+
+// ForEach run f for each item in the group by DoIndexed, the errors have the index of their items.
+// it does not wait for the tasks, so Wait must be called.
+func ForEach[T any](ctx context.Context, g *WaitGroup, items []T, f func(ctx context.Context, index int, item T) error) {
+	for index, item := range items {
+		index, item := index, item
+
+		g.DoIndexed(ctx, index, func(ctx context.Context) error {
+			return f(ctx, index, item)
+		})
+	}
+}
+
+// IndexOf return the index of the item that err belongs to, see WaitGroup.DoIndexed.
+func IndexOf(err error) (int, bool) {
+	return FindFieldInChain(KeyIndex, err).IntValue()
+}
+
+// DoTimeout is like DoNamed, but ctx of f has the timeout instead of the timeout of WaitGroupWithTaskTimeout.
+// name can be empty.
+func (g *WaitGroup) DoTimeout(ctx context.Context, name string, timeout time.Duration, f func(ctx context.Context) error) {
+	g.acquire()
+	g.start(ctx, task{name: name, f: f, timeout: timeout})
+}
+
+// RetryOptions configure the retry of a task, see WaitGroup.DoRetry.
+type RetryOptions struct {
+	// Attempts is the max number of calls of the task, values less than 1 mean one call.
+	Attempts int
+
+	// Backoff return the delay before the next attempt, attempt starts from 1.
+	// if it's nil, the delay of RetryAfter is used, or no delay.
+	Backoff func(attempt int, err error) time.Duration
+
+	// RetryIf report whether the error must be retried, if it's nil, all errors are retried.
+	RetryIf func(err error) bool
+}
+
+// DoRetry is like DoCtx, but f is retried based on options, the error of the last attempt is recorded
+// with the number of attempts (KeyAttempts) as field. retries stop when ctx is done.
+func (g *WaitGroup) DoRetry(ctx context.Context, f func(ctx context.Context) error, options RetryOptions) {
+	g.DoCtx(ctx, func(ctx context.Context) error {
+		return retry(ctx, f, options)
+	})
+}
+
+// retry call f until it succeeds, the error is not retryable, attempts are done or ctx is done.
+func retry(ctx context.Context, f func(ctx context.Context) error, options RetryOptions) error {
+	attempt := 1
+
+	err := f(ctx)
+	for err != nil && attempt < options.Attempts && (options.RetryIf == nil || options.RetryIf(err)) {
+		var delay time.Duration
+		if options.Backoff != nil {
+			delay = options.Backoff(attempt, err)
+		} else {
+			delay, _ = RetryAfter(err)
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return wrap(1, err, "", []Field{Int(KeyAttempts, attempt)}) // skip retry
+		case <-timer.C:
+		}
+
+		attempt++
+		err = f(ctx)
+	}
 
 	if err == nil {
+		return nil
+	}
+
+	return wrap(1, err, "", []Field{Int(KeyAttempts, attempt)}) // skip retry
+}
+
+// TryDo is like DoCtx, but returns false without running f if the task limit is reached (see SetLimit),
+// so callers can shed load instead of blocking.
+func (g *WaitGroup) TryDo(ctx context.Context, f func(ctx context.Context) error) bool {
+	if !g.limiter.tryAcquire() {
+		return false
+	}
+
+	g.start(ctx, task{f: f, timeout: g.taskTimeout})
+
+	return true
+}
+
+// acquire wait for the limit of SetLimit, the wait duration is reported to OnLimitWait hook.
+func (g *WaitGroup) acquire() {
+	if g.hooks.OnLimitWait == nil {
+		g.limiter.acquire()
+
+		return
+	}
+
+	started := time.Now()
+	g.limiter.acquire()
+	g.hooks.OnLimitWait(time.Since(started))
+}
+
+// Go call f in a new goroutine, it's errgroup.Group.Go of golang.org/x/sync, see NewErrGroup.
+func (g *WaitGroup) Go(f func() error) {
+	g.acquire()
+	g.start(g.Context(), task{f: func(context.Context) error { return f() }, timeout: g.taskTimeout})
+}
+
+// TryGo is like Go, but returns false and does not call f if the limit of SetLimit is reached,
+// it's errgroup.Group.TryGo of golang.org/x/sync.
+func (g *WaitGroup) TryGo(f func() error) bool {
+	return g.TryDo(g.Context(), func(context.Context) error { return f() })
+}
+
+// NewErrGroup create a WaitGroup in first error mode with ctx as the parent of its context, and return the group
+// context too. the context is canceled by the first error or when Wait returns, whichever occurs first.
+// it's errgroup.WithContext of golang.org/x/sync, so errgroup code can switch by changing the constructor.
+//
+//	g, ctx := errors.NewErrGroup(ctx)
+//	g.Go(func() error { return fetch(ctx) })
+//	err := g.Wait()
+func NewErrGroup(ctx context.Context, options ...WaitGroupOption) (*WaitGroup, context.Context) {
+	options = append([]WaitGroupOption{WaitGroupWithContext(ctx), WaitGroupWithFirstError()}, options...)
+	group := NewWaitGroup(options...)
+
+	return group, group.Context()
+}
+
+// task is a function that run by WaitGroup.
+type task struct {
+	name    string // if it's not empty, the error is wrapped with the name and index of the task.
+	timeout time.Duration
+	f       func(ctx context.Context) error
+}
+
+// start run the task in a new goroutine, the limiter must be acquired.
+func (g *WaitGroup) start(ctx context.Context, t task) {
+	index := int(atomic.AddInt64(&g.tasks, 1) - 1)
+
+	g.Add(1)
+
+	// the task returns after the caller of Do, so the stack of the caller is captured here.
+	var stack []uintptr
+	if g.errorStacks {
+		stack = spawnStack(g.errorStackDepth)
+	}
+
+	if atomic.LoadInt32(&g.draining) == 1 {
+		g.limiter.release()
+		g.done(wrap(0, ErrDraining, "", taskFields(t.name, index)), stack)
+
+		return
+	}
+
+	if g.rateLimiter != nil {
+		if err := g.rateLimiter.Wait(ctx); err != nil {
+			g.limiter.release()
+			g.done(wrap(0, err, "rate limit", taskFields(t.name, index)), stack)
+
+			return
+		}
+	}
+
+	g.runningMx.Lock()
+	if g.running == nil {
+		g.running = make(map[int]string)
+	}
+	g.running[index] = t.name
+	g.runningMx.Unlock()
+
+	run := func() {
+		if g.hooks.OnTaskStart != nil {
+			g.hooks.OnTaskStart(t.name, index)
+		}
+
+		started := time.Now()
+
+		var err error
+		if g.taskWrapper != nil {
+			err = g.taskWrapper(ctx, t.name, index, func(ctx context.Context) error { return g.runTask(ctx, t) })
+		} else {
+			err = g.runTask(ctx, t)
+		}
+
+		if err != nil && t.name != "" {
+			err = wrap(0, err, "", taskFields(t.name, index))
+		}
+
+		if g.hooks.OnTaskDone != nil {
+			g.hooks.OnTaskDone(t.name, index, err, time.Since(started))
+		}
+
+		g.runningMx.Lock()
+		delete(g.running, index)
+		g.runningMx.Unlock()
+
+		g.limiter.release()
+		g.done(err, stack)
+	}
+
+	if g.pool != nil {
+		g.pool.submit(run)
+
 		return
 	}
 
-	g.errors.Add(err)
+	go run()
+}
+
+// groupFunctions are the prefixes of the functions that start tasks, they are skipped by spawnStack.
+var groupFunctions = []string{
+	"github.com/mrsoftware/errors.(*WaitGroup).",
+	"github.com/mrsoftware/errors.(*ResultGroup[",
+	"github.com/mrsoftware/errors.(*Pipeline).",
+	"github.com/mrsoftware/errors.ForEach[",
+}
+
+// spawnStack captures the stack of the goroutine that start a task with the depth,
+// the frames of the group are skipped, so the stack starts from the caller of Do.
+func spawnStack(depth StacktraceDepth) []uintptr {
+	if depth == StacktraceNone {
+		return nil
+	}
+
+	pcs := captureStack(1, StacktraceFull) // skip spawnStack
+	for len(pcs) != 0 && isGroupFunction(pcs[0]) {
+		pcs = pcs[1:]
+	}
+
+	switch {
+	case depth == StacktraceFirst && len(pcs) > 1:
+		pcs = pcs[:1]
+	case depth > StacktraceFull && int(depth) < len(pcs):
+		pcs = pcs[:depth]
+	}
+
+	return pcs
+}
+
+// isGroupFunction report whether pc is in one of groupFunctions.
+func isGroupFunction(pc uintptr) bool {
+	fn := runtime.FuncForPC(pc - 1)
+	if fn == nil {
+		return false
+	}
+
+	for _, prefix := range groupFunctions {
+		if strings.HasPrefix(fn.Name(), prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasStack report whether an error of the chain has a stack.
+func hasStack(err error) bool {
+	for ; err != nil; err = stdErr.Unwrap(err) {
+		if custom, ok := err.(*Error); ok && len(custom.stack) != 0 { // nolint: errorlint
+			return true
+		}
+	}
+
+	return false
+}
+
+// taskFields return the fields of the task, the name is not included if it's empty.
+func taskFields(name string, index int) []Field {
+	if name == "" {
+		return []Field{Int(KeyTaskIndex, index)}
+	}
+
+	return []Field{String(KeyTask, name), Int(KeyTaskIndex, index)}
+}
+
+// Drain stop accepting new tasks and wait for the running tasks until ctx is done, it's used for graceful shutdown.
+// new tasks are not run and ErrDraining is recorded for them. if ctx is done before the running tasks,
+// the returned MultiError has the recorded errors and an error for each abandoned task, with its name (KeyTask)
+// and index (KeyTaskIndex) as fields. the group can not be reused after Drain.
+func (g *WaitGroup) Drain(ctx context.Context) error {
+	atomic.StoreInt32(&g.draining, 1)
+
+	err := g.WaitContext(ctx)
+	if err == nil || err != ctx.Err() { // nolint: errorlint
+		return err
+	}
+
+	g.runningMx.Lock()
+	indexes := make([]int, 0, len(g.running))
+	for index := range g.running {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	abandoned := make([]error, 0, len(indexes))
+	for _, index := range indexes {
+		abandoned = append(abandoned, wrap(0, err, "task abandoned", taskFields(g.running[index], index)))
+	}
+	g.runningMx.Unlock()
+
+	g.errors.mx.Lock()
+	recorded := append([]error(nil), g.errors.errors...)
+	g.errors.mx.Unlock()
+
+	return NewMultiError(append(recorded, abandoned...)...)
+}
+
+// runTask run the task with its timeout, if the timeout is exceeded the error is wrapped as a timeout error.
+func (g *WaitGroup) runTask(ctx context.Context, t task) error {
+	if t.timeout <= 0 {
+		return g.run(ctx, t.f)
+	}
+
+	taskCtx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	err := g.run(taskCtx, t.f)
+	if err != nil && ctx.Err() == nil && stdErr.Is(taskCtx.Err(), context.DeadlineExceeded) {
+		return wrap(0, err, "task timeout", []Field{Duration(KeyTimeout, t.timeout)})
+	}
+
+	return err
+}
+
+// run call f, the panic of f is recovered if panic recovery is enabled.
+func (g *WaitGroup) run(ctx context.Context, f func(ctx context.Context) error) (err error) {
+	if g.panicRecovery {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = newPanicError(recovered)
+			}
+		}()
+	}
+
+	return f(ctx)
+}
+
+// TaskOf return the name and index of the task that err belongs to, see WaitGroup.DoNamed.
+func TaskOf(err error) (name string, index int, ok bool) {
+	name, ok = FindFieldInChain(KeyTask, err).StringValue()
+	if !ok {
+		return "", 0, false
+	}
+
+	index, _ = FindFieldInChain(KeyTaskIndex, err).IntValue()
+
+	return name, index, true
+}
+
+// newPanicError create an error of the recovered panic value, if the value is an error it's the cause.
+func newPanicError(recovered interface{}) error {
+	// skip newPanicError and the deferred function, so the stack starts from the panic.
+	fields := []Field{Reflect(KeyPanic, recovered), LazyStackSkipDepth(KeyStack, 2, StacktraceFull)}
+
+	if cause, ok := recovered.(error); ok {
+		return wrap(1, cause, "panic", fields) // skip newPanicError
+	}
+
+	return wrap(1, nil, fmt.Sprintf("panic: %v", recovered), fields) // skip newPanicError
+}
+
+// IsPanic report whether err is a recovered panic, see WaitGroupWithPanicRecovery.
+func IsPanic(err error) bool {
+	return !IsNilField(FindFieldInChain(KeyPanic, err))
+}
+
+// workerPool run the submitted jobs by its workers, the workers and the queue are created when a job is
+// submitted and there is no pending job, and they are released when all pending jobs are done.
+type workerPool struct {
+	mx        sync.Mutex
+	workers   int
+	queueSize int
+	pending   int
+	queue     chan func()
+}
+
+// submit queue the job, it blocks while the queue is full.
+func (p *workerPool) submit(job func()) {
+	p.mx.Lock()
+	if p.pending == 0 {
+		p.queue = make(chan func(), p.queueSize)
+
+		for i := 0; i < p.workers; i++ {
+			go work(p.queue)
+		}
+	}
+
+	p.pending++
+	queue := p.queue
+	p.mx.Unlock()
+
+	// the queue is not closed while the job is pending.
+	queue <- func() {
+		job()
+		p.done()
+	}
+}
+
+func (p *workerPool) done() {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	p.pending--
+	if p.pending == 0 {
+		close(p.queue)
+		p.queue = nil
+	}
+}
+
+func work(queue <-chan func()) {
+	for job := range queue {
+		job()
+	}
+}
+
+// errorStream send the pushed errors to its channel in order, without blocking the pusher.
+type errorStream struct {
+	ch     chan error
+	mx     sync.Mutex
+	cond   *sync.Cond
+	queue  []error
+	closed bool
+}
+
+func newErrorStream() *errorStream {
+	stream := &errorStream{ch: make(chan error)}
+	stream.cond = sync.NewCond(&stream.mx)
+
+	go stream.forward()
+
+	return stream
+}
+
+func (s *errorStream) push(err error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.queue = append(s.queue, err)
+	s.cond.Signal()
+}
+
+func (s *errorStream) close() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.closed = true
+	s.cond.Signal()
+}
+
+// forward send the queued errors to the channel, the channel is closed after the stream is closed and the queue is empty.
+func (s *errorStream) forward() {
+	for {
+		s.mx.Lock()
+		for len(s.queue) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+
+		if len(s.queue) == 0 {
+			s.mx.Unlock()
+			close(s.ch)
+
+			return
+		}
+
+		err := s.queue[0]
+		s.queue = s.queue[1:]
+		s.mx.Unlock()
+
+		s.ch <- err
+	}
+}
+
+// taskLimiter limit the number of running tasks, the limit can change at any time.
+type taskLimiter struct {
+	mx     sync.Mutex
+	cond   *sync.Cond
+	limit  int
+	active int
+}
+
+func (l *taskLimiter) setLimit(n int) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	l.limit = n
+	l.broadcast()
+}
+
+func (l *taskLimiter) acquire() {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	for l.limit > 0 && l.active >= l.limit {
+		if l.cond == nil {
+			l.cond = sync.NewCond(&l.mx)
+		}
+
+		l.cond.Wait()
+	}
+
+	l.active++
+}
+
+func (l *taskLimiter) tryAcquire() bool {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	if l.limit > 0 && l.active >= l.limit {
+		return false
+	}
+
+	l.active++
+
+	return true
+}
+
+func (l *taskLimiter) release() {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	l.active--
+	l.broadcast()
+}
+
+// broadcast wake up the waiting acquire calls, l.mx must be locked.
+func (l *taskLimiter) broadcast() {
+	if l.cond != nil {
+		l.cond.Broadcast()
+	}
 }
 
 // noCopy may be embedded into structs which must not be copied
@@ -0,0 +1,67 @@
+//go:build unix
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SIGWINCH is used, since it's ignored by default, so the test process is not stopped by it.
+func TestWaitGroupWithSignals_Process(t *testing.T) {
+	t.Run("signal arrived while tasks are running, expect tasks canceled and ErrSignal returned", func(t *testing.T) {
+		wg := NewWaitGroup(WaitGroupWithSignals(syscall.SIGWINCH))
+
+		started := make(chan struct{})
+		wg.Do(func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+
+			return nil
+		})
+
+		<-started
+		require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGWINCH))
+
+		var signalErr ErrSignal
+		assert.True(t, errors.As(wg.Wait(), &signalErr))
+		assert.Equal(t, syscall.SIGWINCH, signalErr.Signal)
+	})
+
+	t.Run("signal arrived after wait, expect it's not watched and not recorded", func(t *testing.T) {
+		wg := NewWaitGroup(WaitGroupWithSignals(syscall.SIGWINCH))
+
+		wg.Do(func(ctx context.Context) error { return nil })
+		require.NoError(t, wg.Wait())
+
+		select {
+		case <-wg.signalsDone:
+		default:
+			t.Fatal("signals are still watched after wait")
+		}
+
+		// observe the signal, so the test knows it's delivered.
+		delivered := make(chan os.Signal, 1)
+		signal.Notify(delivered, syscall.SIGWINCH)
+		defer signal.Stop(delivered)
+
+		require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGWINCH))
+
+		select {
+		case <-delivered:
+		case <-time.After(time.Second):
+			t.Fatal("signal is not delivered")
+		}
+
+		assert.Equal(t, context.Canceled, context.Cause(wg.Context()))
+		assert.NoError(t, wg.Wait())
+	})
+}
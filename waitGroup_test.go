@@ -1,10 +1,14 @@
 package errors
 
 import (
+	"context"
 	"errors"
+	"os"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -54,6 +58,902 @@ func TestGroup(t *testing.T) {
 	})
 }
 
+func TestWaitGroupWithErrorStacks(t *testing.T) {
+	t.Run("task failed, expect error with stack of the task", func(t *testing.T) {
+		cause := errors.New("cause")
+		wg := NewWaitGroup(WaitGroupWithErrorStacks(StacktraceFirst))
+
+		wg.Add(1)
+		go func() {
+			wg.Done(cause)
+		}()
+
+		err := wg.Wait()
+		assert.ErrorIs(t, err, cause)
+		assert.Equal(t, "cause", err.Error())
+
+		taskErr, ok := err.(*MultiError).errors[0].(*Error)
+		assert.True(t, ok)
+		assert.True(t, strings.HasPrefix(taskErr.StackTrace(), "github.com/mrsoftware/errors.TestWaitGroupWithErrorStacks.func1"), taskErr.StackTrace())
+		assert.Len(t, taskErr.stack, 1)
+	})
+
+	t.Run("task run by Do failed, expect error with stack of the caller of Do", func(t *testing.T) {
+		wg := NewWaitGroup(WaitGroupWithErrorStacks(StacktraceFull))
+		wg.Do(func(context.Context) error { return errors.New("cause") })
+
+		taskErr, ok := wg.Wait().(*MultiError).errors[0].(*Error)
+		assert.True(t, ok)
+		assert.Equal(t, "github.com/mrsoftware/errors.TestWaitGroupWithErrorStacks.func2", taskErr.Frames()[0].Function)
+	})
+
+	t.Run("error has stack, expect error as is", func(t *testing.T) {
+		cause := WithStack(errors.New("cause"))
+		wg := NewWaitGroup(WaitGroupWithErrorStacks(StacktraceFirst))
+		wg.Do(func(context.Context) error { return cause })
+
+		assert.Equal(t, []error{cause}, wg.Wait().(*MultiError).errors)
+	})
+
+	t.Run("option is not set, expect error as is", func(t *testing.T) {
+		cause := errors.New("cause")
+		wg := NewWaitGroup()
+
+		wg.Add(1)
+		go func() {
+			wg.Done(cause)
+		}()
+
+		assert.Equal(t, []error{cause}, wg.Wait().(*MultiError).errors)
+	})
+}
+
+func TestWaitGroup_Do(t *testing.T) {
+	t.Run("tasks run with context, expect errors of tasks", func(t *testing.T) {
+		type key struct{}
+
+		failure := errors.New("failure")
+		ctx := context.WithValue(context.Background(), key{}, "value")
+		wg := NewWaitGroup()
+
+		wg.DoCtx(ctx, func(ctx context.Context) error {
+			assert.Equal(t, "value", ctx.Value(key{}))
+
+			return nil
+		})
+		wg.DoCtx(ctx, func(ctx context.Context) error { return failure })
+
+		err := wg.Wait()
+		assert.ErrorIs(t, err, failure)
+		assert.Equal(t, 1, err.(*MultiError).Len())
+	})
+
+	t.Run("task panics with panic recovery, expect panic error", func(t *testing.T) {
+		wg := NewWaitGroup(WaitGroupWithPanicRecovery())
+
+		wg.DoCtx(context.Background(), func(ctx context.Context) error { panic("boom") })
+
+		err := wg.Wait()
+		assert.True(t, IsPanic(err))
+		assert.Equal(t, "panic: boom", err.Error())
+		assert.Equal(t, "boom", FindFieldInChain(KeyPanic, err).Value())
+
+		stack := FindFieldInChain(KeyStack, err).Value().(string)
+		assert.True(t, strings.HasPrefix(stack, "runtime.gopanic\n"), stack)
+		assert.Contains(t, stack, "github.com/mrsoftware/errors.TestWaitGroup_Do.func2.1")
+	})
+
+	t.Run("task panics with error value, expect error as cause", func(t *testing.T) {
+		failure := errors.New("failure")
+		wg := NewWaitGroup(WaitGroupWithPanicRecovery())
+
+		wg.DoCtx(context.Background(), func(ctx context.Context) error { panic(failure) })
+
+		err := wg.Wait()
+		assert.True(t, IsPanic(err))
+		assert.ErrorIs(t, err, failure)
+		assert.Equal(t, "panic: failure", err.Error())
+	})
+
+	t.Run("task returns error, expect not panic error", func(t *testing.T) {
+		assert.False(t, IsPanic(errors.New("failure")))
+	})
+}
+
+func TestWaitGroup_WaitContext(t *testing.T) {
+	t.Run("tasks are done in time, expect errors of tasks", func(t *testing.T) {
+		failure := errors.New("failure")
+		wg := NewWaitGroup()
+		wg.DoCtx(context.Background(), func(ctx context.Context) error { return failure })
+
+		assert.ErrorIs(t, wg.WaitContext(context.Background()), failure)
+		assert.ErrorIs(t, wg.WaitTimeout(time.Second), failure)
+	})
+
+	t.Run("no task, expect nil", func(t *testing.T) {
+		assert.NoError(t, NewWaitGroup().WaitTimeout(time.Second))
+	})
+
+	t.Run("context is canceled before tasks are done, expect context error", func(t *testing.T) {
+		release := make(chan struct{})
+		wg := NewWaitGroup()
+		wg.DoCtx(context.Background(), func(ctx context.Context) error {
+			<-release
+
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.Equal(t, context.Canceled, wg.WaitContext(ctx))
+
+		close(release)
+		assert.NoError(t, wg.Wait())
+	})
+
+	t.Run("timeout before tasks are done, expect timeout error", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+
+		wg := NewWaitGroup()
+		wg.DoCtx(context.Background(), func(ctx context.Context) error {
+			<-release
+
+			return nil
+		})
+
+		err := wg.WaitTimeout(time.Millisecond)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, "wait timeout: context deadline exceeded", err.Error())
+	})
+}
+
+func TestWaitGroup_SetLimit(t *testing.T) {
+	// run tasks and return the max number of tasks that ran at the same time.
+	runTasks := func(wg *WaitGroup, n int, onStart func(i int)) int32 {
+		var running, maxRunning int32
+
+		for i := 0; i < n; i++ {
+			i := i
+			wg.DoCtx(context.Background(), func(ctx context.Context) error {
+				current := atomic.AddInt32(&running, 1)
+				for {
+					old := atomic.LoadInt32(&maxRunning)
+					if current <= old || atomic.CompareAndSwapInt32(&maxRunning, old, current) {
+						break
+					}
+				}
+
+				if onStart != nil {
+					onStart(i)
+				}
+
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&running, -1)
+
+				return nil
+			})
+		}
+
+		assert.NoError(t, wg.Wait())
+
+		return atomic.LoadInt32(&maxRunning)
+	}
+
+	t.Run("task limit option, expect running tasks not more than limit", func(t *testing.T) {
+		assert.LessOrEqual(t, runTasks(NewWaitGroup(WaitGroupWithTaskLimit(2)), 10, nil), int32(2))
+	})
+
+	t.Run("limit is changed while tasks are running, expect new limit", func(t *testing.T) {
+		wg := NewWaitGroup(WaitGroupWithTaskLimit(3))
+
+		assert.LessOrEqual(t, runTasks(wg, 10, func(i int) {
+			if i == 0 {
+				wg.SetLimit(1)
+			}
+		}), int32(3))
+
+		assert.Equal(t, int32(1), runTasks(wg, 5, nil))
+	})
+
+	t.Run("limit grows while Do is blocked, expect Do is released", func(t *testing.T) {
+		release := make(chan struct{})
+		wg := NewWaitGroup(WaitGroupWithTaskLimit(1))
+		wg.DoCtx(context.Background(), func(ctx context.Context) error {
+			<-release
+
+			return nil
+		})
+
+		done := make(chan struct{})
+		go func() {
+			wg.DoCtx(context.Background(), func(ctx context.Context) error { return nil })
+			close(done)
+		}()
+
+		wg.SetLimit(2)
+		<-done
+
+		close(release)
+		assert.NoError(t, wg.Wait())
+	})
+}
+
+func TestWaitGroup_TryDo(t *testing.T) {
+	t.Run("no limit, expect task runs", func(t *testing.T) {
+		failure := errors.New("failure")
+		wg := NewWaitGroup()
+
+		assert.True(t, wg.TryDo(context.Background(), func(ctx context.Context) error { return failure }))
+		assert.ErrorIs(t, wg.Wait(), failure)
+	})
+
+	t.Run("limit is reached, expect false without running the task", func(t *testing.T) {
+		release := make(chan struct{})
+		wg := NewWaitGroup(WaitGroupWithTaskLimit(1))
+
+		assert.True(t, wg.TryDo(context.Background(), func(ctx context.Context) error {
+			<-release
+
+			return nil
+		}))
+		assert.False(t, wg.TryDo(context.Background(), func(ctx context.Context) error {
+			t.Error("task must not run")
+
+			return nil
+		}))
+
+		close(release)
+		assert.NoError(t, wg.Wait())
+		assert.True(t, wg.TryDo(context.Background(), func(ctx context.Context) error { return nil }))
+		assert.NoError(t, wg.Wait())
+	})
+}
+
+func TestWaitGroup_DoNamed(t *testing.T) {
+	t.Run("named tasks failed, expect errors with task name and index", func(t *testing.T) {
+		usersErr := errors.New("users failed")
+		ordersErr := errors.New("orders failed")
+		wg := NewWaitGroup()
+
+		wg.DoNamed(context.Background(), "sync-users", func(ctx context.Context) error { return usersErr })
+		wg.DoNamed(context.Background(), "sync-items", func(ctx context.Context) error { return nil })
+		wg.DoNamed(context.Background(), "sync-orders", func(ctx context.Context) error { return ordersErr })
+
+		err := wg.Wait()
+		assert.Equal(t, 2, err.(*MultiError).Len())
+
+		tasks := map[string]int{}
+		for _, taskErr := range err.(*MultiError).errors {
+			name, index, ok := TaskOf(taskErr)
+			assert.True(t, ok)
+
+			tasks[name] = index
+
+			if name == "sync-users" {
+				assert.Equal(t, "users failed", taskErr.Error())
+				assert.ErrorIs(t, taskErr, usersErr)
+			}
+		}
+
+		assert.Equal(t, map[string]int{"sync-users": 0, "sync-orders": 2}, tasks)
+	})
+
+	t.Run("not named task, expect no task", func(t *testing.T) {
+		_, _, ok := TaskOf(errors.New("failure"))
+		assert.False(t, ok)
+	})
+}
+
+func TestWaitGroupWithFirstError(t *testing.T) {
+	t.Run("tasks failed, expect first error and canceled context", func(t *testing.T) {
+		first := errors.New("first")
+		wg := NewWaitGroup(WaitGroupWithFirstError())
+
+		wg.DoCtx(wg.Context(), func(ctx context.Context) error { return first })
+		wg.DoCtx(wg.Context(), func(ctx context.Context) error {
+			<-ctx.Done()
+
+			return ctx.Err()
+		})
+
+		assert.Equal(t, first, wg.Wait())
+		assert.Equal(t, first, context.Cause(wg.Context()))
+	})
+
+	t.Run("no error, expect nil and context canceled by wait without cause", func(t *testing.T) {
+		wg := NewWaitGroup(WaitGroupWithFirstError())
+		wg.DoCtx(wg.Context(), func(ctx context.Context) error {
+			assert.NoError(t, ctx.Err())
+
+			return nil
+		})
+
+		assert.NoError(t, wg.Wait())
+		assert.Equal(t, context.Canceled, context.Cause(wg.Context()))
+	})
+}
+
+func TestNewErrGroup_WaitCancel(t *testing.T) {
+	t.Run("wait returned, expect the derived context canceled", func(t *testing.T) {
+		g, ctx := NewErrGroup(context.Background())
+		g.Go(func() error { return nil })
+
+		assert.NoError(t, g.Wait())
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			t.Fatal("context is not canceled after wait")
+		}
+	})
+
+	t.Run("wait returned with errors, expect the first error as cause", func(t *testing.T) {
+		failure := errors.New("failure")
+		wg := NewWaitGroup()
+		wg.Do(func(ctx context.Context) error { return failure })
+
+		assert.Error(t, wg.Wait())
+		assert.Equal(t, failure, context.Cause(wg.Context()))
+	})
+}
+
+func TestWaitGroup_Context(t *testing.T) {
+	t.Run("parent context is canceled, expect group context canceled", func(t *testing.T) {
+		parent, cancel := context.WithCancel(context.Background())
+		wg := NewWaitGroup(WaitGroupWithContext(parent))
+
+		cancel()
+		assert.Equal(t, context.Canceled, wg.Context().Err())
+	})
+
+	t.Run("stop, expect group context canceled", func(t *testing.T) {
+		wg := NewWaitGroup()
+		wg.Stop()
+
+		assert.Equal(t, context.Canceled, wg.Context().Err())
+	})
+
+	t.Run("zero value, expect background context", func(t *testing.T) {
+		wg := &WaitGroup{}
+		wg.Stop()
+
+		assert.NoError(t, wg.Context().Err())
+	})
+}
+
+func TestWaitGroup_Errors(t *testing.T) {
+	t.Run("tasks failed, expect errors while other tasks are running", func(t *testing.T) {
+		failure := errors.New("failure")
+		release := make(chan struct{})
+		wg := NewWaitGroup()
+		errs := wg.Errors()
+
+		wg.DoCtx(context.Background(), func(ctx context.Context) error { return failure })
+		wg.DoCtx(context.Background(), func(ctx context.Context) error {
+			<-release
+
+			return nil
+		})
+
+		assert.Equal(t, failure, <-errs)
+		close(release)
+
+		assert.ErrorIs(t, wg.Wait(), failure)
+
+		_, ok := <-errs
+		assert.False(t, ok)
+	})
+
+	t.Run("receiver is slow, expect tasks are not blocked and all errors received", func(t *testing.T) {
+		wg := NewWaitGroup()
+		errs := wg.Errors()
+
+		for i := 0; i < 10; i++ {
+			wg.DoCtx(context.Background(), func(ctx context.Context) error { return errors.New("failure") })
+		}
+
+		assert.Error(t, wg.Wait())
+
+		count := 0
+		for range errs {
+			count++
+		}
+
+		assert.Equal(t, 10, count)
+	})
+}
+
+func TestWaitGroup_DoRetry(t *testing.T) {
+	t.Run("task succeeds after retries, expect no error", func(t *testing.T) {
+		var calls int32
+		wg := NewWaitGroup()
+
+		wg.DoRetry(context.Background(), func(ctx context.Context) error {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				return errors.New("transient")
+			}
+
+			return nil
+		}, RetryOptions{Attempts: 3, Backoff: func(int, error) time.Duration { return time.Millisecond }})
+
+		assert.NoError(t, wg.Wait())
+		assert.Equal(t, int32(3), calls)
+	})
+
+	t.Run("task always fails, expect last error with attempts", func(t *testing.T) {
+		var calls int32
+		failure := errors.New("failure")
+		wg := NewWaitGroup()
+
+		wg.DoRetry(context.Background(), func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+
+			return failure
+		}, RetryOptions{Attempts: 3})
+
+		err := wg.Wait()
+		assert.Equal(t, int32(3), calls)
+		assert.Equal(t, "failure", err.Error())
+		assert.Equal(t, int64(3), FindFieldInChain(KeyAttempts, err).Value())
+	})
+
+	t.Run("error is not retryable, expect one attempt", func(t *testing.T) {
+		var calls int32
+		wg := NewWaitGroup()
+
+		wg.DoRetry(context.Background(), func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+
+			return errors.New("permanent")
+		}, RetryOptions{Attempts: 3, RetryIf: IsRetryable})
+
+		assert.Error(t, wg.Wait())
+		assert.Equal(t, int32(1), calls)
+	})
+
+	t.Run("context is canceled while waiting, expect no more attempts", func(t *testing.T) {
+		var calls int32
+		ctx, cancel := context.WithCancel(context.Background())
+		wg := NewWaitGroup()
+
+		wg.DoRetry(ctx, func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			cancel()
+
+			return RetryableAfter(errors.New("transient"), time.Hour)
+		}, RetryOptions{Attempts: 3})
+
+		err := wg.Wait()
+		assert.Equal(t, int32(1), calls)
+		assert.Equal(t, int64(1), FindFieldInChain(KeyAttempts, err).Value())
+	})
+}
+
+func TestWaitGroupWithTaskTimeout(t *testing.T) {
+	slowTask := func(ctx context.Context) error {
+		<-ctx.Done()
+
+		return ctx.Err()
+	}
+
+	t.Run("task exceeds the timeout, expect timeout error with task name", func(t *testing.T) {
+		wg := NewWaitGroup(WaitGroupWithTaskTimeout(time.Millisecond))
+		wg.DoNamed(context.Background(), "slow", slowTask)
+		wg.DoCtx(context.Background(), func(ctx context.Context) error { return nil })
+
+		err := wg.Wait()
+		assert.Equal(t, 1, err.(*MultiError).Len())
+		assert.ErrorIs(t, err.(*MultiError).errors[0], context.DeadlineExceeded)
+		assert.Equal(t, "task timeout: context deadline exceeded", err.Error())
+		assert.Equal(t, time.Millisecond, FindFieldInChain(KeyTimeout, err).Value())
+
+		name, _, ok := TaskOf(err)
+		assert.True(t, ok)
+		assert.Equal(t, "slow", name)
+	})
+
+	t.Run("per call timeout, expect timeout of the call", func(t *testing.T) {
+		wg := NewWaitGroup(WaitGroupWithTaskTimeout(time.Hour))
+		wg.DoTimeout(context.Background(), "", time.Millisecond, slowTask)
+
+		assert.Equal(t, time.Millisecond, FindFieldInChain(KeyTimeout, wg.Wait()).Value())
+	})
+
+	t.Run("parent context is canceled, expect not timeout error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		wg := NewWaitGroup(WaitGroupWithTaskTimeout(time.Hour))
+		wg.DoCtx(ctx, slowTask)
+
+		assert.Equal(t, "context canceled", wg.Wait().Error())
+	})
+}
+
+func TestForEach(t *testing.T) {
+	t.Run("some items failed, expect errors with index of items", func(t *testing.T) {
+		items := []string{"a", "", "c", ""}
+		wg := NewWaitGroup()
+
+		ForEach(context.Background(), wg, items, func(ctx context.Context, index int, item string) error {
+			if item == "" {
+				return errors.New("empty item")
+			}
+
+			return nil
+		})
+
+		err := wg.Wait()
+		indexes := []int{}
+		for _, itemErr := range err.(*MultiError).errors {
+			index, ok := IndexOf(itemErr)
+			assert.True(t, ok)
+			assert.Equal(t, "empty item", itemErr.Error())
+
+			indexes = append(indexes, index)
+		}
+
+		assert.ElementsMatch(t, []int{1, 3}, indexes)
+	})
+
+	t.Run("no index, expect false", func(t *testing.T) {
+		_, ok := IndexOf(errors.New("failure"))
+		assert.False(t, ok)
+	})
+}
+
+func TestWaitGroupWithWorkers(t *testing.T) {
+	t.Run("many tasks, expect all tasks run by the workers", func(t *testing.T) {
+		var running, maxRunning, done int32
+		wg := NewWaitGroup(WaitGroupWithWorkers(3, 10))
+
+		for i := 0; i < 100; i++ {
+			wg.DoCtx(context.Background(), func(ctx context.Context) error {
+				current := atomic.AddInt32(&running, 1)
+				for {
+					old := atomic.LoadInt32(&maxRunning)
+					if current <= old || atomic.CompareAndSwapInt32(&maxRunning, old, current) {
+						break
+					}
+				}
+
+				atomic.AddInt32(&running, -1)
+				atomic.AddInt32(&done, 1)
+
+				return nil
+			})
+		}
+
+		assert.NoError(t, wg.Wait())
+		assert.Equal(t, int32(100), done)
+		assert.LessOrEqual(t, maxRunning, int32(3))
+	})
+
+	t.Run("group is reused after wait, expect tasks run", func(t *testing.T) {
+		failure := errors.New("failure")
+		wg := NewWaitGroup(WaitGroupWithWorkers(1, 0))
+
+		wg.DoCtx(context.Background(), func(ctx context.Context) error { return nil })
+		assert.NoError(t, wg.Wait())
+
+		wg.DoCtx(context.Background(), func(ctx context.Context) error { return failure })
+		assert.ErrorIs(t, wg.Wait(), failure)
+	})
+}
+
+func TestWaitGroup_Drain(t *testing.T) {
+	t.Run("running tasks are done before ctx, expect their errors", func(t *testing.T) {
+		failure := errors.New("failure")
+		wg := NewWaitGroup()
+
+		wg.DoCtx(context.Background(), func(ctx context.Context) error { return failure })
+
+		assert.ErrorIs(t, wg.Drain(context.Background()), failure)
+	})
+
+	t.Run("task added while draining, expect it is not run", func(t *testing.T) {
+		var called int32
+		wg := NewWaitGroup()
+
+		release := make(chan struct{})
+		wg.DoNamed(context.Background(), "slow", func(ctx context.Context) error {
+			<-release
+
+			return nil
+		})
+
+		drained := make(chan error)
+		go func() { drained <- wg.Drain(context.Background()) }()
+
+		for atomic.LoadInt32(&wg.draining) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+
+		wg.DoNamed(context.Background(), "late", func(ctx context.Context) error {
+			atomic.AddInt32(&called, 1)
+
+			return nil
+		})
+		close(release)
+
+		err := <-drained
+		var multi *MultiError
+		assert.True(t, errors.As(err, &multi))
+		assert.Equal(t, 1, multi.Len())
+		assert.True(t, errors.Is(multi.Errors()[0], ErrDraining))
+
+		name, index, ok := TaskOf(multi.Errors()[0])
+		assert.True(t, ok)
+		assert.Equal(t, "late", name)
+		assert.Equal(t, 1, index)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&called))
+	})
+
+	t.Run("ctx is done before running tasks, expect abandoned tasks as errors", func(t *testing.T) {
+		failure := errors.New("failure")
+		wg := NewWaitGroup()
+
+		release := make(chan struct{})
+		defer close(release)
+
+		wg.DoCtx(context.Background(), func(ctx context.Context) error { return failure })
+		assert.Eventually(t, func() bool { return wg.errors.SafeLen() == 1 }, time.Second, time.Millisecond)
+
+		wg.DoNamed(context.Background(), "stuck", func(ctx context.Context) error {
+			<-release
+
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := wg.Drain(ctx)
+		var multi *MultiError
+		assert.True(t, errors.As(err, &multi))
+		assert.Equal(t, 2, multi.Len())
+		assert.Equal(t, failure, multi.Errors()[0])
+		assert.True(t, errors.Is(multi.Errors()[1], context.DeadlineExceeded))
+
+		name, index, ok := TaskOf(multi.Errors()[1])
+		assert.True(t, ok)
+		assert.Equal(t, "stuck", name)
+		assert.Equal(t, 1, index)
+	})
+}
+
+func TestWaitGroupWithSignals(t *testing.T) {
+	t.Run("signal arrived, expect context canceled and ErrSignal recorded", func(t *testing.T) {
+		wg := NewWaitGroup()
+
+		signals := make(chan os.Signal, 1)
+		signals <- os.Interrupt
+		wg.watchSignals(signals, nil)
+
+		assert.ErrorIs(t, wg.Context().Err(), context.Canceled)
+
+		var signalErr ErrSignal
+		assert.True(t, errors.As(context.Cause(wg.Context()), &signalErr))
+		assert.Equal(t, os.Interrupt, signalErr.Signal)
+		assert.ErrorIs(t, wg.Wait(), ErrSignal{Signal: os.Interrupt})
+	})
+
+	t.Run("group stopped before any signal, expect no error", func(t *testing.T) {
+		wg := NewWaitGroup(WaitGroupWithSignals(os.Interrupt))
+		wg.Stop()
+
+		wg.watchSignals(make(chan os.Signal), nil)
+
+		assert.NoError(t, wg.Wait())
+	})
+}
+
+func TestWaitGroupWithHooks(t *testing.T) {
+	t.Run("tasks are done, expect hooks called for each task", func(t *testing.T) {
+		failure := errors.New("failure")
+
+		var started, failed, waits int32
+		wg := NewWaitGroup(WaitGroupWithHooks(WaitGroupHooks{
+			OnTaskStart: func(name string, index int) { atomic.AddInt32(&started, 1) },
+			OnTaskDone: func(name string, index int, err error, duration time.Duration) {
+				if err != nil {
+					assert.Equal(t, "fail", name)
+					atomic.AddInt32(&failed, 1)
+				}
+			},
+			OnLimitWait: func(duration time.Duration) { atomic.AddInt32(&waits, 1) },
+		}))
+		wg.SetLimit(1)
+
+		wg.DoCtx(context.Background(), func(ctx context.Context) error { return nil })
+		wg.DoNamed(context.Background(), "fail", func(ctx context.Context) error { return failure })
+
+		assert.Error(t, wg.Wait())
+		assert.Equal(t, int32(2), atomic.LoadInt32(&started))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&failed))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&waits))
+	})
+}
+
+func TestWaitGroupWithTaskWrapper(t *testing.T) {
+	t.Run("task has error, expect wrapper get the error and its context passed to the task", func(t *testing.T) {
+		type key struct{}
+
+		failure := errors.New("failure")
+
+		var wrapped error
+		wg := NewWaitGroup(WaitGroupWithTaskWrapper(func(ctx context.Context, name string, index int, next func(ctx context.Context) error) error {
+			assert.Equal(t, "task", name)
+			assert.Equal(t, 0, index)
+
+			wrapped = next(context.WithValue(ctx, key{}, "value"))
+
+			return wrapped
+		}))
+
+		wg.DoNamed(context.Background(), "task", func(ctx context.Context) error {
+			assert.Equal(t, "value", ctx.Value(key{}))
+
+			return failure
+		})
+
+		assert.Error(t, wg.Wait())
+		assert.Equal(t, failure, wrapped)
+	})
+}
+
+type testRateLimiter struct {
+	waits int32
+}
+
+func (l *testRateLimiter) Wait(ctx context.Context) error {
+	atomic.AddInt32(&l.waits, 1)
+
+	return ctx.Err()
+}
+
+func TestWaitGroupWithRateLimiter(t *testing.T) {
+	t.Run("tasks started, expect limiter waited for each task", func(t *testing.T) {
+		var called int32
+		limiter := &testRateLimiter{}
+		wg := NewWaitGroup(WaitGroupWithRateLimiter(limiter))
+
+		for i := 0; i < 3; i++ {
+			wg.DoCtx(context.Background(), func(ctx context.Context) error {
+				atomic.AddInt32(&called, 1)
+
+				return nil
+			})
+		}
+
+		assert.NoError(t, wg.Wait())
+		assert.Equal(t, int32(3), atomic.LoadInt32(&limiter.waits))
+		assert.Equal(t, int32(3), atomic.LoadInt32(&called))
+	})
+
+	t.Run("task context is done while waiting, expect task not run and its error recorded", func(t *testing.T) {
+		var called int32
+		wg := NewWaitGroup(WaitGroupWithRateLimiter(&testRateLimiter{}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		wg.DoNamed(ctx, "canceled", func(ctx context.Context) error {
+			atomic.AddInt32(&called, 1)
+
+			return nil
+		})
+
+		err := wg.Wait()
+		var multi *MultiError
+		assert.True(t, errors.As(err, &multi))
+		assert.True(t, errors.Is(multi.Errors()[0], context.Canceled))
+
+		name, _, ok := TaskOf(multi.Errors()[0])
+		assert.True(t, ok)
+		assert.Equal(t, "canceled", name)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&called))
+	})
+}
+
+func TestNewErrGroup(t *testing.T) {
+	t.Run("a task failed, expect first error and context canceled", func(t *testing.T) {
+		failure := errors.New("failure")
+		g, ctx := NewErrGroup(context.Background())
+
+		g.Go(func() error { return failure })
+		g.Go(func() error {
+			<-ctx.Done()
+
+			return ctx.Err()
+		})
+
+		assert.Equal(t, failure, g.Wait())
+		assert.ErrorIs(t, context.Cause(ctx), failure)
+	})
+
+	t.Run("limit is reached, expect TryGo returns false", func(t *testing.T) {
+		g, _ := NewErrGroup(context.Background())
+		g.SetLimit(1)
+
+		release := make(chan struct{})
+		assert.True(t, g.TryGo(func() error {
+			<-release
+
+			return nil
+		}))
+		assert.False(t, g.TryGo(func() error { return nil }))
+
+		close(release)
+		assert.NoError(t, g.Wait())
+	})
+}
+
+func TestWaitGroup_DoGroupContext(t *testing.T) {
+	t.Run("group is stopped, expect the task get the group context", func(t *testing.T) {
+		wg := NewWaitGroup()
+		wg.Stop()
+
+		wg.Do(func(ctx context.Context) error { return ctx.Err() })
+
+		err := wg.Wait()
+		var multi *MultiError
+		assert.True(t, errors.As(err, &multi))
+		assert.Equal(t, context.Canceled, multi.Errors()[0])
+	})
+}
+
+func TestWaitGroupWithErrorMapper(t *testing.T) {
+	t.Run("tasks failed, expect the mapped errors recorded and nil errors dropped", func(t *testing.T) {
+		failure := errors.New("failure")
+		wg := NewWaitGroup(WaitGroupWithErrorMapper(func(err error) error {
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+
+			return Wrap(err, "mapped", String("job", "sync"))
+		}))
+
+		wg.Do(func(ctx context.Context) error { return failure })
+		wg.Do(func(ctx context.Context) error { return context.Canceled })
+		wg.Do(func(ctx context.Context) error { return nil })
+
+		err := wg.Wait()
+		var multi *MultiError
+		assert.True(t, errors.As(err, &multi))
+		assert.Equal(t, 1, multi.Len())
+		assert.True(t, errors.Is(multi.Errors()[0], failure))
+		assert.Equal(t, "mapped: failure", multi.Errors()[0].Error())
+	})
+}
+
+func TestWaitGroupIgnore(t *testing.T) {
+	t.Run("tasks canceled by the first error, expect only the first error", func(t *testing.T) {
+		failure := errors.New("failure")
+		notFound := errors.New("not found")
+		wg := NewWaitGroup(WaitGroupIgnore(context.Canceled, notFound))
+
+		wg.Do(func(ctx context.Context) error { return failure })
+		wg.Do(func(ctx context.Context) error { return Wrap(notFound, "load user") })
+		wg.Do(func(ctx context.Context) error { return context.Canceled })
+
+		err := wg.Wait()
+		var multi *MultiError
+		assert.True(t, errors.As(err, &multi))
+		assert.Equal(t, []error{failure}, multi.Errors())
+	})
+
+	t.Run("all errors are ignored, expect no error", func(t *testing.T) {
+		wg := NewWaitGroup(WaitGroupIgnore(context.Canceled))
+
+		wg.Do(func(ctx context.Context) error { return context.Canceled })
+
+		assert.NoError(t, wg.Wait())
+	})
+}
+
 // all below test cases are copied from sync/waitgroup_test.go and transformed to group.
 
 func testWaitGroup(t *testing.T, wg1 *WaitGroup, wg2 *WaitGroup) {
@@ -0,0 +1,79 @@
+// Package zaperrors converts the fields of errors.Error to zap fields, so zap users get typed fields without manual translation.
+package zaperrors
+
+import (
+	"github.com/mrsoftware/errors"
+	"go.uber.org/zap"
+)
+
+// Fields convert the fields of the error chain to zap fields.
+// repeated keys in the chain are handled by errors.GetDedupPolicy, context fields are skipped.
+func Fields(err error) []zap.Field {
+	if err == nil {
+		return nil
+	}
+
+	chain := errors.DedupChainFields(err)
+	fields := make([]zap.Field, 0, len(chain))
+
+	for _, field := range chain {
+		if field.Type != errors.FieldTypeContext {
+			fields = append(fields, ToZap(field))
+		}
+	}
+
+	return fields
+}
+
+// ToZap convert the field to zap field, context fields are converted to zap.Skip.
+func ToZap(field errors.Field) zap.Field {
+	switch field.Type { // nolint: exhaustive
+	case errors.FieldTypeString:
+		return zap.String(field.Key, field.Str)
+	case errors.FieldTypeInt64:
+		return zap.Int64(field.Key, field.Integer)
+	case errors.FieldTypeFloat64:
+		value, _ := field.Float64Value()
+
+		return zap.Float64(field.Key, value)
+	case errors.FieldTypeBool:
+		value, _ := field.BoolValue()
+
+		return zap.Bool(field.Key, value)
+	case errors.FieldTypeBinary:
+		value, _ := field.BinaryValue()
+
+		return zap.Binary(field.Key, value)
+	case errors.FieldTypeByteString:
+		value, _ := field.BinaryValue()
+
+		return zap.ByteString(field.Key, value)
+	case errors.FieldTypeTime, errors.FieldTypeTimeFull:
+		value, _ := field.TimeValue()
+
+		return zap.Time(field.Key, value)
+	case errors.FieldTypeDuration:
+		value, _ := field.DurationValue()
+
+		return zap.Duration(field.Key, value)
+	case errors.FieldTypeError:
+		value, _ := field.ErrorValue()
+
+		return zap.NamedError(field.Key, value)
+	case errors.FieldTypeNamespace:
+		fields, _ := field.NamespaceValue()
+		nested := make([]zap.Field, 0, len(fields))
+
+		for _, item := range fields {
+			nested = append(nested, ToZap(item))
+		}
+
+		return zap.Dict(field.Key, nested...)
+	case errors.FieldTypeContext:
+		return zap.Skip()
+	case errors.FieldTypeReflect:
+		return zap.Reflect(field.Key, field.Interface)
+	default:
+		return zap.Any(field.Key, field.Value())
+	}
+}
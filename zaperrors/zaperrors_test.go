@@ -0,0 +1,64 @@
+package zaperrors_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	"github.com/mrsoftware/errors"
+	"github.com/mrsoftware/errors/zaperrors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error has fields in chain, expect typed zap fields", func(t *testing.T) {
+		cause := errors.New("cause", errors.String("id", "1"), errors.Int("attempt", 2))
+		err := errors.Wrap(cause, "some error",
+			errors.String("id", "2"),
+			errors.Duration("took", time.Second),
+			errors.NamedContext("ctx", context.Background()),
+		)
+
+		assert.Equal(t, []zap.Field{
+			zap.String("id", "2"),
+			zap.Duration("took", time.Second),
+			zap.Int64("attempt", 2),
+		}, zaperrors.Fields(err))
+	})
+
+	t.Run("nil error, expect nil", func(t *testing.T) {
+		assert.Nil(t, zaperrors.Fields(nil))
+	})
+
+	t.Run("log with zap, expect fields in the entry", func(t *testing.T) {
+		core, logs := observer.New(zapcore.InfoLevel)
+		logger := zap.New(core)
+
+		err := errors.New("some error", errors.CodeField(errors.CodeNotFound), errors.Bool("cached", true))
+		logger.Error("failed", zaperrors.Fields(err)...)
+
+		assert.Equal(t, map[string]interface{}{"code": "not_found", "cached": true}, logs.All()[0].ContextMap())
+	})
+}
+
+func TestToZap(t *testing.T) {
+	t.Parallel()
+
+	cause := stdErrors.New("cause")
+
+	assert.Equal(t, zap.Float64("key", 1.5), zaperrors.ToZap(errors.Float64("key", 1.5)))
+	assert.Equal(t, zap.Binary("key", []byte{1}), zaperrors.ToZap(errors.Binary("key", []byte{1})))
+	assert.Equal(t, zap.ByteString("key", []byte("value")), zaperrors.ToZap(errors.ByteString("key", []byte("value"))))
+	assert.Equal(t, zap.NamedError("key", cause), zaperrors.ToZap(errors.NamedError("key", cause)))
+	assert.Equal(t, zap.Skip(), zaperrors.ToZap(errors.NamedContext("key", context.Background())))
+	assert.Equal(t,
+		zap.Dict("db", zap.String("table", "users"), zap.Int64("rows", 0)),
+		zaperrors.ToZap(errors.Namespace("db", errors.String("table", "users"), errors.Int("rows", 0))),
+	)
+}
@@ -0,0 +1,87 @@
+// Package zerologerrors enriches zerolog events with the fields of errors.Error, so zerolog users can emit the structured fields directly.
+package zerologerrors
+
+import (
+	"github.com/mrsoftware/errors"
+	"github.com/rs/zerolog"
+)
+
+// Enrich add the fields of the error chain to the event with their types.
+// repeated keys in the chain are handled by errors.GetDedupPolicy, context fields are skipped.
+//
+//	zerologerrors.Enrich(logger.Error().Err(err), err).Msg("failed")
+func Enrich(event *zerolog.Event, err error) *zerolog.Event {
+	if err == nil || event == nil {
+		return event
+	}
+
+	for _, field := range errors.DedupChainFields(err) {
+		if field.Type != errors.FieldTypeContext {
+			event = addField(event, field)
+		}
+	}
+
+	return event
+}
+
+// Dict return a dictionary of the error message and its fields, to be used with zerolog.Event.Dict.
+//
+//	logger.Error().Dict("error", zerologerrors.Dict(err)).Msg("failed")
+func Dict(err error) *zerolog.Event {
+	dict := zerolog.Dict()
+	if err == nil {
+		return dict
+	}
+
+	return Enrich(dict.Str(errors.KeyMessage, err.Error()), err)
+}
+
+func addField(event *zerolog.Event, field errors.Field) *zerolog.Event {
+	switch field.Type { // nolint: exhaustive
+	case errors.FieldTypeString:
+		return event.Str(field.Key, field.Str)
+	case errors.FieldTypeInt64:
+		return event.Int64(field.Key, field.Integer)
+	case errors.FieldTypeFloat64:
+		value, _ := field.Float64Value()
+
+		return event.Float64(field.Key, value)
+	case errors.FieldTypeBool:
+		value, _ := field.BoolValue()
+
+		return event.Bool(field.Key, value)
+	case errors.FieldTypeBinary:
+		value, _ := field.BinaryValue()
+
+		return event.Hex(field.Key, value)
+	case errors.FieldTypeByteString:
+		value, _ := field.BinaryValue()
+
+		return event.Bytes(field.Key, value)
+	case errors.FieldTypeTime, errors.FieldTypeTimeFull:
+		value, _ := field.TimeValue()
+
+		return event.Time(field.Key, value)
+	case errors.FieldTypeDuration:
+		value, _ := field.DurationValue()
+
+		return event.Dur(field.Key, value)
+	case errors.FieldTypeError:
+		value, _ := field.ErrorValue()
+
+		return event.AnErr(field.Key, value)
+	case errors.FieldTypeNamespace:
+		fields, _ := field.NamespaceValue()
+		dict := zerolog.Dict()
+
+		for _, item := range fields {
+			if item.Type != errors.FieldTypeContext {
+				dict = addField(dict, item)
+			}
+		}
+
+		return event.Dict(field.Key, dict)
+	default:
+		return event.Interface(field.Key, field.Value())
+	}
+}
@@ -0,0 +1,83 @@
+package zerologerrors_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mrsoftware/errors"
+	"github.com/mrsoftware/errors/zerologerrors"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnrich(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error has fields in chain, expect fields in the event", func(t *testing.T) {
+		cause := errors.New("cause", errors.String("id", "1"), errors.Int("attempt", 2))
+		err := errors.Wrap(cause, "some error",
+			errors.String("id", "2"),
+			errors.Bool("cached", true),
+			errors.NamedContext("ctx", context.Background()),
+		)
+
+		record := log(t, func(logger zerolog.Logger) {
+			zerologerrors.Enrich(logger.Error().Err(err), err).Msg("failed")
+		})
+
+		assert.Equal(t, map[string]interface{}{
+			"level":   "error",
+			"error":   "some error: cause",
+			"id":      "2",
+			"cached":  true,
+			"attempt": float64(2),
+			"message": "failed",
+		}, record)
+	})
+
+	t.Run("error has namespace, expect nested object", func(t *testing.T) {
+		err := errors.New("some error", errors.Namespace("db", errors.String("table", "users")))
+
+		record := log(t, func(logger zerolog.Logger) {
+			zerologerrors.Enrich(logger.Error(), err).Msg("failed")
+		})
+
+		assert.Equal(t, map[string]interface{}{"table": "users"}, record["db"])
+	})
+
+	t.Run("nil error, expect event as is", func(t *testing.T) {
+		record := log(t, func(logger zerolog.Logger) {
+			zerologerrors.Enrich(logger.Error(), nil).Msg("failed")
+		})
+
+		assert.Equal(t, map[string]interface{}{"level": "error", "message": "failed"}, record)
+	})
+}
+
+func TestDict(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("some error", errors.CodeField(errors.CodeNotFound), errors.Duration("took", time.Second))
+
+	record := log(t, func(logger zerolog.Logger) {
+		logger.Error().Dict("error", zerologerrors.Dict(err)).Msg("failed")
+	})
+
+	assert.Equal(t, map[string]interface{}{"message": "some error", "code": "not_found", "took": float64(1000)}, record["error"])
+}
+
+func log(t *testing.T, fn func(logger zerolog.Logger)) map[string]interface{} {
+	t.Helper()
+
+	buffer := &bytes.Buffer{}
+	fn(zerolog.New(buffer))
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &record))
+
+	return record
+}